@@ -5,6 +5,8 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"strings"
 )
 
 var _ = Describe("Bag configuration methods", func() {
@@ -106,4 +108,34 @@ var _ = Describe("Bag configuration methods", func() {
 			Expect(result).To(BeNil())
 		})
 	})
-})
\ No newline at end of file
+
+	Describe("Dictionary", func() {
+		It("Should be created from the words of the custom tokenizer", func() {
+			bag.SetWordTokenizer(func(sentence string) []string {
+				return strings.Split(strings.ToUpper(strings.TrimSuffix(sentence, ".")), " ")
+			})
+			result, err := bag.Summarize("Cats chase mice. Dogs chase cats. Mice fear cats.", 1)
+			Expect(err).To(BeNil())
+			Expect(bag.Dict).To(HaveKey("CHASE"))
+			// vectors of words missing from the dictionary would all be zero and rank nothing
+			Expect(result).To(HaveLen(1))
+		})
+
+		It("Should be created again for every text", func() {
+			_, err := bag.Summarize("Cats chase mice. Dogs chase cats. Mice fear cats.", 1)
+			Expect(err).To(BeNil())
+			_, err = bag.Summarize("Birds sing songs. Fish swim fast. Birds fly high.", 1)
+			Expect(err).To(BeNil())
+			Expect(bag.Dict).To(HaveKey("birds"))
+			Expect(bag.Dict).NotTo(HaveKey("cats"))
+		})
+
+		It("Should keep the dictionary set by the user", func() {
+			dict := map[string]int{"cats": 1, "mice": 2}
+			bag.SetDictionary(dict)
+			_, err := bag.Summarize("Cats chase mice. Dogs chase cats. Mice fear cats.", 1)
+			Expect(err).To(BeNil())
+			Expect(bag.Dict).To(Equal(dict))
+		})
+	})
+})
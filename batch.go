@@ -0,0 +1,99 @@
+package tldr
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// BatchRecord is a text to summarize in a batch, as in {"id": ..., "text": ...}
+type BatchRecord struct {
+	ID   json.RawMessage `json:"id,omitempty"` // any JSON value, written back as it was read
+	Text string          `json:"text"`
+}
+
+// BatchResult is the summary of a record of a batch
+type BatchResult struct {
+	ID        json.RawMessage `json:"id,omitempty"`
+	Language  string          `json:"language,omitempty"`
+	Sentences []string        `json:"sentences"`
+	Indexes   []int           `json:"indexes"` // position of every selected sentence in the text
+	Scores    []float64       `json:"scores"`  // score of every selected sentence, the highest of the text is 1
+	Error     string          `json:"error,omitempty"`
+}
+
+// SummarizeBatch summarizes every record to num sentences with the settings of the bag.
+// A record failing does not stop the batch, its result has an Error instead.
+func (bag *Bag) SummarizeBatch(records []*BatchRecord, num int) []*BatchResult {
+	results := make([]*BatchResult, 0, len(records))
+	for _, record := range records {
+		results = append(results, bag.summarizeRecord(record, num))
+	}
+	return results
+}
+
+// ProcessBatch reads JSON lines records from r, summarizes each of them to num sentences,
+// and writes a JSON line result for each of them to w, in the same order. Blank lines are skipped.
+// Records that cannot be decoded or summarized are reported in the Error of their result,
+// so the returned error is only about reading r or writing w.
+func (bag *Bag) ProcessBatch(r io.Reader, w io.Writer, num int) error {
+	reader := bufio.NewReader(r)
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("tldr: reading line %d: %w", line, err)
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			var result *BatchResult
+			record := &BatchRecord{}
+			if decodeErr := json.Unmarshal(data, record); decodeErr != nil {
+				result = &BatchResult{Error: fmt.Sprintf("line %d: %v", line, decodeErr)}
+			} else {
+				result = bag.summarizeRecord(record, num)
+			}
+			if encodeErr := encoder.Encode(result); encodeErr != nil {
+				return fmt.Errorf("tldr: writing line %d: %w", line, encodeErr)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("tldr: writing results: %w", err)
+	}
+	return nil
+}
+
+// summarizeRecord summarizes the text of a record, along with the index and score of every selected sentence
+func (bag *Bag) summarizeRecord(record *BatchRecord, num int) *BatchResult {
+	result := &BatchResult{
+		ID:        record.ID,
+		Sentences: []string{},
+		Indexes:   []int{},
+		Scores:    []float64{},
+	}
+
+	// an empty text would summarize the sentences of the last record again
+	bag.OriginalSentences = nil
+	idx, err := bag.summarize(record.Text, num)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Language = bag.languageName()
+
+	for _, sentence := range bag.summarySentences(idx) {
+		result.Sentences = append(result.Sentences, sentence.Text)
+		result.Indexes = append(result.Indexes, sentence.Index)
+		result.Scores = append(result.Scores, sentence.Score)
+	}
+	return result
+}
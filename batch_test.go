@@ -0,0 +1,90 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+var _ = Describe("ProcessBatch()", func() {
+	It("Should write a result for every record in the same order", func() {
+		sums, err := New().Summarize(text, 3)
+		Expect(err).To(BeNil())
+
+		input := &bytes.Buffer{}
+		encoder := json.NewEncoder(input)
+		Expect(encoder.Encode(map[string]interface{}{"id": "a", "text": text})).To(Succeed())
+		input.WriteString("\n")
+		Expect(encoder.Encode(map[string]interface{}{"id": 2, "text": "Birds sing songs. Fish swim fast. Birds fly high."})).To(Succeed())
+
+		output := &bytes.Buffer{}
+		Expect(New().ProcessBatch(input, output, 3)).To(Succeed())
+
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		first := &BatchResult{}
+		Expect(json.Unmarshal([]byte(lines[0]), first)).To(Succeed())
+		Expect(string(first.ID)).To(Equal(`"a"`))
+		Expect(first.Sentences).To(Equal(sums))
+		Expect(first.Indexes).To(HaveLen(3))
+		Expect(first.Scores).To(HaveLen(3))
+		for _, score := range first.Scores {
+			Expect(score).To(BeNumerically(">", 0))
+			Expect(score).To(BeNumerically("<=", 1))
+		}
+		Expect(first.Error).To(BeEmpty())
+
+		second := &BatchResult{}
+		Expect(json.Unmarshal([]byte(lines[1]), second)).To(Succeed())
+		Expect(string(second.ID)).To(Equal("2"))
+		Expect(second.Sentences).To(HaveLen(3))
+	})
+
+	It("Should report a malformed record without stopping the batch", func() {
+		input := strings.NewReader(`{"id": 1, "text": "Birds sing songs. Fish swim fast."}
+{"id": 2, "text":
+{"id": 3, "text": ""}`)
+		output := &bytes.Buffer{}
+		Expect(New().ProcessBatch(input, output, 1)).To(Succeed())
+
+		results := []*BatchResult{}
+		decoder := json.NewDecoder(output)
+		for decoder.More() {
+			result := &BatchResult{}
+			Expect(decoder.Decode(result)).To(Succeed())
+			results = append(results, result)
+		}
+		Expect(results).To(HaveLen(3))
+		Expect(results[0].Error).To(BeEmpty())
+		Expect(results[0].Sentences).To(HaveLen(1))
+		Expect(results[1].Error).To(HavePrefix("line 2:"))
+		Expect(string(results[2].ID)).To(Equal("3"))
+		Expect(results[2].Error).To(BeEmpty())
+		Expect(results[2].Sentences).To(BeEmpty())
+	})
+})
+
+var _ = Describe("SummarizeBatch()", func() {
+	It("Should summarize every record with the same settings", func() {
+		bag := New()
+		bag.Algorithm = "centrality"
+		results := bag.SummarizeBatch([]*BatchRecord{
+			{ID: []byte(`"x"`), Text: text},
+			{Text: "  "},
+		}, 3)
+		Expect(results).To(HaveLen(2))
+
+		centrality := New()
+		centrality.Algorithm = "centrality"
+		sums, err := centrality.Summarize(text, 3)
+		Expect(err).To(BeNil())
+		Expect(results[0].Sentences).To(Equal(sums))
+		Expect(results[1].Sentences).To(BeEmpty())
+	})
+})
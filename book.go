@@ -0,0 +1,432 @@
+package tldr
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Book is a book split into chapters, read from an EPUB file or a plain text
+type Book struct {
+	Title    string
+	Author   string
+	Chapters []*Chapter // in reading order
+}
+
+// Chapter is a chapter of a book
+type Chapter struct {
+	Title string
+	Path  string // path of the chapter in the EPUB file, empty for plain text
+	Text  string // paragraphs separated by blank lines
+}
+
+// BookSummary is the result of summarizing a book chapter by chapter
+type BookSummary struct {
+	Title     string
+	Language  string
+	Chapters  []*ChapterSummary // in reading order
+	Sentences []*BookSentence   // overall summary, in reading order
+}
+
+// ChapterSummary is the summary of a chapter.
+// The Start and End of its sentences are byte offsets in the Text of the chapter.
+type ChapterSummary struct {
+	Chapter   *Chapter
+	Sentences []*Sentence
+}
+
+// BookSentence is a sentence of the overall summary of a book, along with the chapter it came from
+type BookSentence struct {
+	*Sentence
+	Chapter int // index of the chapter in the book
+}
+
+var chapterHeading = regexp.MustCompile(`(?im)^[ \t]*(?:chapter|part|book|section)[ \t]+(?:\d+|[ivxlcdm]+|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|thirteen|fourteen|fifteen|sixteen|seventeen|eighteen|nineteen|twenty|the [a-z]+)\b[^\n]*$`)
+
+// SplitChapters splits a plain text book into chapters at lines like "Chapter 1" or "CHAPTER IV. The End".
+// Text before the first chapter, like a preface, is a chapter without title.
+func SplitChapters(text string) *Book {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	book := &Book{}
+	headings := chapterHeading.FindAllStringIndex(text, -1)
+	if len(headings) == 0 {
+		if strings.TrimSpace(text) != "" {
+			book.Chapters = append(book.Chapters, &Chapter{Text: strings.TrimSpace(text)})
+		}
+		return book
+	}
+
+	if preface := strings.TrimSpace(text[:headings[0][0]]); preface != "" {
+		book.Chapters = append(book.Chapters, &Chapter{Text: preface})
+	}
+	for i, heading := range headings {
+		end := len(text)
+		if i+1 < len(headings) {
+			end = headings[i+1][0]
+		}
+		book.Chapters = append(book.Chapters, &Chapter{
+			Title: strings.TrimSpace(text[heading[0]:heading[1]]),
+			Text:  strings.TrimSpace(text[heading[1]:end]),
+		})
+	}
+	return book
+}
+
+// ParseEPUB reads the chapters of an EPUB file in the order of its spine.
+// Titles come from the table of contents, or the first heading of the chapters.
+// Chapters without text, like covers, are left out. Only documents in UTF-8 are read.
+func ParseEPUB(r io.ReaderAt, size int64) (*Book, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("tldr: reading epub: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var container struct {
+		Rootfiles []struct {
+			FullPath string `xml:"full-path,attr"`
+		} `xml:"rootfiles>rootfile"`
+	}
+	if err := readEPUBXML(files, "META-INF/container.xml", &container); err != nil {
+		return nil, err
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, fmt.Errorf("tldr: reading epub: no rootfile in META-INF/container.xml")
+	}
+	opfPath := container.Rootfiles[0].FullPath
+
+	var opf struct {
+		Title    []string `xml:"metadata>title"`
+		Creator  []string `xml:"metadata>creator"`
+		Manifest []struct {
+			ID         string `xml:"id,attr"`
+			Href       string `xml:"href,attr"`
+			MediaType  string `xml:"media-type,attr"`
+			Properties string `xml:"properties,attr"`
+		} `xml:"manifest>item"`
+		Spine struct {
+			Toc      string `xml:"toc,attr"`
+			Itemrefs []struct {
+				IDRef  string `xml:"idref,attr"`
+				Linear string `xml:"linear,attr"`
+			} `xml:"itemref"`
+		} `xml:"spine"`
+	}
+	if err := readEPUBXML(files, opfPath, &opf); err != nil {
+		return nil, err
+	}
+
+	book := &Book{}
+	if len(opf.Title) > 0 {
+		book.Title = collapseSpaces(opf.Title[0])
+	}
+	if len(opf.Creator) > 0 {
+		book.Author = collapseSpaces(opf.Creator[0])
+	}
+
+	hrefs := make(map[string]string)
+	titles := make(map[string]string)
+	for _, item := range opf.Manifest {
+		href := epubPath(opfPath, item.Href)
+		hrefs[item.ID] = href
+		switch {
+		case strings.Contains(" "+item.Properties+" ", " nav "):
+			readEPUBNav(files, href, titles)
+		case item.ID == opf.Spine.Toc || item.MediaType == "application/x-dtbncx+xml":
+			readEPUBNCX(files, href, titles)
+		}
+	}
+
+	for _, itemref := range opf.Spine.Itemrefs {
+		href, exists := hrefs[itemref.IDRef]
+		if !exists || itemref.Linear == "no" {
+			continue
+		}
+		file, exists := files[href]
+		if !exists {
+			return nil, fmt.Errorf("tldr: reading epub: missing chapter %s", href)
+		}
+		text, heading, err := readXHTML(file)
+		if err != nil {
+			return nil, err
+		}
+		if text == "" {
+			continue
+		}
+		title := titles[href]
+		if title == "" {
+			title = heading
+		}
+		book.Chapters = append(book.Chapters, &Chapter{
+			Title: title,
+			Path:  href,
+			Text:  text,
+		})
+	}
+	return book, nil
+}
+
+// epubPath resolves a link found in the file at from to the path of a file in the archive
+func epubPath(from, href string) string {
+	if at := strings.IndexByte(href, '#'); at >= 0 {
+		href = href[:at]
+	}
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	return path.Join(path.Dir(from), href)
+}
+
+func readEPUBXML(files map[string]*zip.File, name string, v interface{}) error {
+	file, exists := files[name]
+	if !exists {
+		return fmt.Errorf("tldr: reading epub: missing %s", name)
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("tldr: reading epub: %w", err)
+	}
+	defer rc.Close()
+
+	decoder := newXHTMLDecoder(rc)
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("tldr: reading epub %s: %w", name, err)
+	}
+	return nil
+}
+
+// readEPUBNCX reads the chapter titles of an EPUB 2 table of contents
+func readEPUBNCX(files map[string]*zip.File, name string, titles map[string]string) {
+	var ncx struct {
+		NavPoints []navPoint `xml:"navMap>navPoint"`
+	}
+	if readEPUBXML(files, name, &ncx) != nil {
+		return
+	}
+	var walk func(points []navPoint)
+	walk = func(points []navPoint) {
+		for _, point := range points {
+			href := epubPath(name, point.Content.Src)
+			if titles[href] == "" {
+				titles[href] = collapseSpaces(point.Label)
+			}
+			walk(point.NavPoints)
+		}
+	}
+	walk(ncx.NavPoints)
+}
+
+type navPoint struct {
+	Label   string `xml:"navLabel>text"`
+	Content struct {
+		Src string `xml:"src,attr"`
+	} `xml:"content"`
+	NavPoints []navPoint `xml:"navPoint"`
+}
+
+// readEPUBNav reads the chapter titles of an EPUB 3 navigation document, from the links of its nav elements
+func readEPUBNav(files map[string]*zip.File, name string, titles map[string]string) {
+	file, exists := files[name]
+	if !exists {
+		return
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return
+	}
+	defer rc.Close()
+
+	decoder := newXHTMLDecoder(rc)
+	href, label := "", ""
+	inLink := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "a" {
+				inLink, label, href = true, "", ""
+				for _, a := range t.Attr {
+					if a.Name.Local == "href" {
+						href = epubPath(name, a.Value)
+					}
+				}
+			}
+		case xml.CharData:
+			if inLink {
+				label += string(t)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "a" && inLink {
+				inLink = false
+				if href != "" && titles[href] == "" {
+					titles[href] = collapseSpaces(label)
+				}
+			}
+		}
+	}
+}
+
+// elements of an XHTML chapter ending a paragraph
+var xhtmlBlocks = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "figcaption": true, "figure": true, "footer": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "td": true, "th": true, "tr": true, "ul": true,
+}
+
+// readXHTML reads the text of an XHTML chapter, one paragraph for every block element,
+// along with its first heading
+func readXHTML(file *zip.File) (string, string, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return "", "", fmt.Errorf("tldr: reading epub: %w", err)
+	}
+	defer rc.Close()
+
+	decoder := newXHTMLDecoder(rc)
+	var paragraphs []string
+	var current strings.Builder
+	heading, inHeading := "", false
+	skip := 0
+	flush := func() {
+		if paragraph := collapseSpaces(current.String()); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+		current.Reset()
+	}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("tldr: reading epub %s: %w", file.Name, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch name := strings.ToLower(t.Name.Local); {
+			case name == "head" || name == "script" || name == "style":
+				skip++
+			case xhtmlBlocks[name]:
+				flush()
+				if len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6' && heading == "" {
+					inHeading = true
+				}
+			}
+		case xml.EndElement:
+			switch name := strings.ToLower(t.Name.Local); {
+			case name == "head" || name == "script" || name == "style":
+				skip--
+			case xhtmlBlocks[name]:
+				if inHeading {
+					heading = collapseSpaces(current.String())
+					inHeading = false
+				}
+				flush()
+			}
+		case xml.CharData:
+			if skip == 0 {
+				current.Write(t)
+			}
+		}
+	}
+	flush()
+	return strings.Join(paragraphs, "\n\n"), heading, nil
+}
+
+// newXHTMLDecoder creates a lenient decoder, knowing the HTML entities and elements without end tags
+func newXHTMLDecoder(r io.Reader) *xml.Decoder {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = asciiReader
+	return decoder
+}
+
+// asciiReader reads the documents declared in US-ASCII, a subset of UTF-8, the only encoding supported
+func asciiReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "us-ascii", "ascii", "utf8":
+		return input, nil
+	}
+	return nil, fmt.Errorf("unsupported encoding %q, only UTF-8 is", charset)
+}
+
+// SummarizeEPUB summarizes every chapter of an EPUB file, see SummarizeBook
+func (bag *Bag) SummarizeEPUB(r io.ReaderAt, size int64, perChapter, overall int) (*BookSummary, error) {
+	book, err := ParseEPUB(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return bag.SummarizeBook(book, perChapter, overall)
+}
+
+// SummarizeBook summarizes every chapter of a book to perChapter sentences, paragraphs end sentences.
+// The overall summary is the top overall sentences among the ones of the chapter summaries.
+func (bag *Bag) SummarizeBook(book *Book, perChapter, overall int) (*BookSummary, error) {
+	summary := &BookSummary{
+		Title: book.Title,
+	}
+
+	var sample strings.Builder
+	for _, chapter := range book.Chapters {
+		if sample.Len() >= languageDetectionSize {
+			break
+		}
+		sample.WriteString(chapter.Text)
+		sample.WriteString("\n\n")
+	}
+	bag.resolveLanguage(sample.String())
+	summary.Language = bag.languageName()
+
+	var selected []string
+	var owners []int
+	var spans [][2]int
+	for i, chapter := range book.Chapters {
+		pre := Preprocess(chapter.Text, bag.preprocessors...)
+		var sentences []string
+		for _, paragraph := range strings.Split(pre.Text, "\n\n") {
+			sentences = append(sentences, bag.splitBlock(paragraph)...)
+		}
+
+		idx, err := bag.summarizeSentences(pre, sentences, nil, perChapter)
+		if err != nil {
+			return nil, fmt.Errorf("tldr: chapter %d: %w", i+1, err)
+		}
+		chapterSummary := &ChapterSummary{
+			Chapter:   chapter,
+			Sentences: bag.summarySentences(idx),
+		}
+		summary.Chapters = append(summary.Chapters, chapterSummary)
+		for _, sentence := range chapterSummary.Sentences {
+			selected = append(selected, sentence.Text)
+			owners = append(owners, i)
+			spans = append(spans, [2]int{sentence.Start, sentence.End})
+		}
+	}
+
+	idx, err := bag.summarizeSentences(nil, selected, nil, overall)
+	if err != nil {
+		return nil, err
+	}
+	for _, sentence := range bag.summarySentences(idx) {
+		sentence.Start, sentence.End = spans[sentence.Index][0], spans[sentence.Index][1]
+		summary.Sentences = append(summary.Sentences, &BookSentence{
+			Sentence: sentence,
+			Chapter:  owners[sentence.Index],
+		})
+	}
+	return summary, nil
+}
@@ -0,0 +1,146 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"archive/zip"
+	"bytes"
+	"strings"
+)
+
+// epub builds an EPUB file holding the files
+func epub(files map[string]string) *bytes.Reader {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range []string{"mimetype", "META-INF/container.xml", "OEBPS/content.opf", "OEBPS/toc.ncx", "OEBPS/cover.xhtml", "OEBPS/text/one.xhtml", "OEBPS/text/two.xhtml"} {
+		f, err := w.Create(name)
+		Expect(err).To(BeNil())
+		f.Write([]byte(files[name]))
+	}
+	Expect(w.Close()).To(BeNil())
+	return bytes.NewReader(buf.Bytes())
+}
+
+var epubFiles = map[string]string{
+	"mimetype": "application/epub+zip",
+	"META-INF/container.xml": `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`,
+	"OEBPS/content.opf": `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>The Lighthouse</dc:title>
+    <dc:creator>Jane Writer</dc:creator>
+  </metadata>
+  <manifest>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="cover" href="cover.xhtml" media-type="application/xhtml+xml"/>
+    <item id="one" href="text/one.xhtml" media-type="application/xhtml+xml"/>
+    <item id="two" href="text/two.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine toc="ncx">
+    <itemref idref="cover"/>
+    <itemref idref="one"/>
+    <itemref idref="two"/>
+  </spine>
+</package>`,
+	"OEBPS/toc.ncx": `<?xml version="1.0"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <navMap>
+    <navPoint id="p1"><navLabel><text>The Storm</text></navLabel><content src="text/one.xhtml#start"/></navPoint>
+  </navMap>
+</ncx>`,
+	"OEBPS/cover.xhtml": `<html xmlns="http://www.w3.org/1999/xhtml"><head><title>Cover</title></head><body><img src="cover.jpg"/></body></html>`,
+	"OEBPS/text/one.xhtml": `<html xmlns="http://www.w3.org/1999/xhtml"><head><title>One</title><style>p { margin: 0 }</style></head><body>
+<h1>Chapter One</h1>
+<p>The storm reached the lighthouse at night&nbsp;and the keeper lit the lamp.</p>
+<p>The keeper watched the storm from the top of the lighthouse.<br/>Ships passed the rocks safely.</p>
+<p>In the morning the storm was gone and the sea was calm.</p>
+</body></html>`,
+	"OEBPS/text/two.xhtml": `<html xmlns="http://www.w3.org/1999/xhtml"><head><title>Two</title></head><body>
+<h2>The Visitor</h2>
+<p>A visitor came to the lighthouse with a letter for the keeper.</p>
+<p>The letter said the lighthouse would close next year.</p>
+<p>The keeper read the letter twice and said nothing</p>
+</body></html>`,
+}
+
+var _ = Describe("Books", func() {
+	Describe("ParseEPUB()", func() {
+		It("Should read the chapters in the order of the spine", func() {
+			r := epub(epubFiles)
+			book, err := ParseEPUB(r, r.Size())
+			Expect(err).To(BeNil())
+			Expect(book.Title).To(Equal("The Lighthouse"))
+			Expect(book.Author).To(Equal("Jane Writer"))
+			Expect(book.Chapters).To(HaveLen(2))
+			Expect(book.Chapters[0].Title).To(Equal("The Storm"))
+			Expect(book.Chapters[0].Path).To(Equal("OEBPS/text/one.xhtml"))
+			Expect(book.Chapters[0].Text).To(Equal("Chapter One\n\n" +
+				"The storm reached the lighthouse at night and the keeper lit the lamp.\n\n" +
+				"The keeper watched the storm from the top of the lighthouse.\n\nShips passed the rocks safely.\n\n" +
+				"In the morning the storm was gone and the sea was calm."))
+			Expect(book.Chapters[1].Title).To(Equal("The Visitor"))
+		})
+
+		It("Should only read UTF-8", func() {
+			files := make(map[string]string, len(epubFiles))
+			for name, file := range epubFiles {
+				files[name] = file
+			}
+			files["OEBPS/text/two.xhtml"] = `<?xml version="1.0" encoding="US-ASCII"?>` + "\n" + epubFiles["OEBPS/text/two.xhtml"]
+			r := epub(files)
+			book, err := ParseEPUB(r, r.Size())
+			Expect(err).To(BeNil())
+			Expect(book.Chapters).To(HaveLen(2))
+
+			files["OEBPS/text/two.xhtml"] = `<?xml version="1.0" encoding="ISO-8859-1"?>` + "\n" + epubFiles["OEBPS/text/two.xhtml"]
+			r = epub(files)
+			_, err = ParseEPUB(r, r.Size())
+			Expect(err).To(MatchError(ContainSubstring(`unsupported encoding "ISO-8859-1"`)))
+		})
+
+		It("Should report files that are not EPUB", func() {
+			r := strings.NewReader("not a zip file")
+			_, err := ParseEPUB(r, r.Size())
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("SplitChapters()", func() {
+		It("Should split at chapter headings", func() {
+			book := SplitChapters("A short preface.\n\nCHAPTER I. The Start\n\nIt begins here.\n\nChapter 2\n\nIt ends here.\n")
+			Expect(book.Chapters).To(HaveLen(3))
+			Expect(book.Chapters[0].Title).To(Equal(""))
+			Expect(book.Chapters[1].Title).To(Equal("CHAPTER I. The Start"))
+			Expect(book.Chapters[1].Text).To(Equal("It begins here."))
+			Expect(book.Chapters[2].Title).To(Equal("Chapter 2"))
+		})
+	})
+
+	Describe("SummarizeEPUB()", func() {
+		It("Should summarize every chapter and the whole book", func() {
+			r := epub(epubFiles)
+			summary, err := New().SummarizeEPUB(r, r.Size(), 2, 2)
+			Expect(err).To(BeNil())
+			Expect(summary.Title).To(Equal("The Lighthouse"))
+			Expect(summary.Chapters).To(HaveLen(2))
+			for _, chapter := range summary.Chapters {
+				Expect(chapter.Sentences).To(HaveLen(2))
+				for _, sentence := range chapter.Sentences {
+					Expect(chapter.Chapter.Text[sentence.Start:sentence.End]).To(Equal(sentence.Text))
+				}
+			}
+
+			Expect(summary.Sentences).To(HaveLen(2))
+			for _, sentence := range summary.Sentences {
+				chapter := summary.Chapters[sentence.Chapter].Chapter
+				Expect(chapter.Text[sentence.Start:sentence.End]).To(Equal(sentence.Text))
+			}
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package tldr
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ChatMessage is a message of a chat conversation, like the ones of a Slack export
+type ChatMessage struct {
+	ID        string // used by the replies to the message, can be empty
+	Author    string
+	Time      time.Time
+	Text      string
+	ReplyTo   string // ID of the message this one replies to, empty if none
+	Reactions int    // number of reactions to the message
+}
+
+// ChatSummary is the result of summarizing a conversation
+type ChatSummary struct {
+	Language  string
+	Sentences []*ChatSentence // selected messages, in the order of the conversation
+}
+
+// ChatSentence is a message selected from a conversation.
+// Its Text is the text of the message on one line, and its Start and End are byte offsets in the Text of the message.
+type ChatSentence struct {
+	*Sentence
+	Message *ChatMessage
+	Replies int // number of replies to the message
+}
+
+// SummarizeChat summarizes a conversation to num messages. Every message is one sentence,
+// whether it ends with punctuation or not. When ReplyWeight or ReactionWeight are set,
+// messages with more replies or reactions rank higher.
+func (bag *Bag) SummarizeChat(messages []*ChatMessage, num int) (*ChatSummary, error) {
+	replies := make(map[string]int)
+	for _, message := range messages {
+		if message.ReplyTo != "" {
+			replies[message.ReplyTo]++
+		}
+	}
+
+	var sentences []string
+	var owners []int
+	var spans [][2]int
+	for i, message := range messages {
+		pre := Preprocess(message.Text, bag.preprocessors...)
+		text := collapseSpaces(pre.Text)
+		if text == "" {
+			continue
+		}
+		start := strings.IndexFunc(pre.Text, func(r rune) bool { return !unicode.IsSpace(r) })
+		end := len(strings.TrimRightFunc(pre.Text, unicode.IsSpace))
+		sentences = append(sentences, text)
+		owners = append(owners, i)
+		spans = append(spans, [2]int{pre.OriginalOffset(start), pre.OriginalOffset(end)})
+	}
+	bag.resolveLanguage(strings.Join(sentences, "\n"))
+
+	var boosts []float64
+	if bag.ReplyWeight != 0 || bag.ReactionWeight != 0 {
+		boosts = bag.chatBoosts(messages, owners, replies)
+	}
+
+	idx, err := bag.summarizeSentences(nil, sentences, boosts, num)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ChatSummary{
+		Language: bag.languageName(),
+	}
+	for _, sentence := range bag.summarySentences(idx) {
+		message := messages[owners[sentence.Index]]
+		sentence.Start, sentence.End = spans[sentence.Index][0], spans[sentence.Index][1]
+		summary.Sentences = append(summary.Sentences, &ChatSentence{
+			Sentence: sentence,
+			Message:  message,
+			Replies:  replies[message.ID],
+		})
+	}
+	return summary, nil
+}
+
+// chatBoosts gives every message ReplyWeight and ReactionWeight times its replies and reactions,
+// relative to the message with the most of them
+func (bag *Bag) chatBoosts(messages []*ChatMessage, owners []int, replies map[string]int) []float64 {
+	maxReplies, maxReactions := 0, 0
+	for _, i := range owners {
+		if n := replies[messages[i].ID]; messages[i].ID != "" && n > maxReplies {
+			maxReplies = n
+		}
+		if messages[i].Reactions > maxReactions {
+			maxReactions = messages[i].Reactions
+		}
+	}
+
+	boosts := make([]float64, len(owners))
+	for j, i := range owners {
+		if maxReplies > 0 && messages[i].ID != "" {
+			boosts[j] += bag.ReplyWeight * float64(replies[messages[i].ID]) / float64(maxReplies)
+		}
+		if maxReactions > 0 {
+			boosts[j] += bag.ReactionWeight * float64(messages[i].Reactions) / float64(maxReactions)
+		}
+	}
+	return boosts
+}
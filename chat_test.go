@@ -0,0 +1,60 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"time"
+)
+
+var _ = Describe("SummarizeChat()", func() {
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	conversation := func() []*ChatMessage {
+		return []*ChatMessage{
+			{ID: "1", Author: "alice", Time: start, Text: "the deploy of the api failed again this morning"},
+			{ID: "2", Author: "bob", Time: start.Add(time.Minute), Text: "looks like the database migration of the api timed out", ReplyTo: "1"},
+			{ID: "3", Author: "carol", Time: start.Add(2 * time.Minute), Text: "lol"},
+			{ID: "4", Author: "alice", Time: start.Add(3 * time.Minute), Text: "  I will roll back the deploy\nand rerun the migration tonight  ", Reactions: 5},
+			{ID: "5", Author: "dave", Time: start.Add(4 * time.Minute), Text: "lunch anyone?"},
+			{ID: "6", Author: "bob", Time: start.Add(5 * time.Minute), Text: "the migration of the database needs a bigger timeout", ReplyTo: "1"},
+			{ID: "7", Author: "carol", Time: start.Add(6 * time.Minute), Text: ""},
+		}
+	}
+
+	It("Should treat every message as a sentence", func() {
+		messages := conversation()
+		summary, err := New().SummarizeChat(messages, 6)
+		Expect(err).To(BeNil())
+		Expect(summary.Sentences).To(HaveLen(6))
+
+		rollback := summary.Sentences[3]
+		Expect(rollback.Text).To(Equal("I will roll back the deploy and rerun the migration tonight"))
+		Expect(rollback.Message.Author).To(Equal("alice"))
+		Expect(rollback.Message.Text[rollback.Start:rollback.End]).To(Equal("I will roll back the deploy\nand rerun the migration tonight"))
+		Expect(summary.Sentences[0].Replies).To(Equal(2))
+	})
+
+	It("Should rank messages with more replies and reactions higher", func() {
+		bag := New()
+		bag.Algorithm = "custom"
+		bag.SetCustomAlgorithm(func(edges []*Edge) []int {
+			return []int{4, 2, 1, 3, 0, 5}
+		})
+		summary, err := bag.SummarizeChat(conversation(), 1)
+		Expect(err).To(BeNil())
+		Expect(summary.Sentences[0].Message.ID).To(Equal("5"))
+
+		bag.ReactionWeight = 2
+		summary, err = bag.SummarizeChat(conversation(), 1)
+		Expect(err).To(BeNil())
+		Expect(summary.Sentences[0].Message.ID).To(Equal("4"))
+
+		bag.ReactionWeight = 0
+		bag.ReplyWeight = 2
+		summary, err = bag.SummarizeChat(conversation(), 1)
+		Expect(err).To(BeNil())
+		Expect(summary.Sentences[0].Message.ID).To(Equal("1"))
+	})
+})
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/didasy/tldr"
+)
+
+// jsonKeywords is the JSON output of the keywords of a file
+type jsonKeywords struct {
+	File     string          `json:"file,omitempty"`
+	Language string          `json:"language,omitempty"`
+	Keywords []*tldr.Keyword `json:"keywords"`
+}
+
+// keywords prints the keywords of the files and returns the exit code
+func keywords(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	bag, opts, fs, err := parseFlags(args, stderr, func(bag *tldr.Bag, opts *options, output io.Writer) *flag.FlagSet {
+		fs := flag.NewFlagSet("tldr keywords", flag.ContinueOnError)
+		fs.SetOutput(output)
+		fs.Usage = func() {
+			fmt.Fprintln(fs.Output(), "Usage: tldr keywords [flags] [file ...]")
+			fmt.Fprintln(fs.Output(), "Prints the keywords of the files, or the standard input if there is none or a file is -.")
+			fs.PrintDefaults()
+		}
+		fs.IntVar(&opts.keywords, "n", 10, "number of keywords")
+		fs.StringVar(&opts.format, "format", "text", `output format: "text", a keyword per line, or "json"`)
+		addBagFlags(fs, bag, opts)
+		return fs
+	})
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if opts.keywords < 1 {
+		fmt.Fprintln(stderr, "tldr: -n must be at least 1")
+		return 2
+	}
+	if opts.format != "text" && opts.format != "json" {
+		fmt.Fprintf(stderr, "tldr: unknown format %q\n", opts.format)
+		return 2
+	}
+	if err := configure(bag, opts); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for i, file := range files {
+		text, err := readFile(file, stdin)
+		if err != nil {
+			fmt.Fprintln(stderr, "tldr:", err)
+			return 1
+		}
+		extracted, err := bag.ExtractKeywords(text, opts.keywords)
+		if err != nil {
+			fmt.Fprintln(stderr, "tldr:", err)
+			return 1
+		}
+		if len(files) == 1 {
+			file = ""
+		}
+
+		if opts.format == "json" {
+			encoder := json.NewEncoder(stdout)
+			encoder.SetEscapeHTML(false)
+			err = encoder.Encode(&jsonKeywords{File: file, Language: extracted.Language, Keywords: extracted.Keywords})
+		} else {
+			var b strings.Builder
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			if file != "" {
+				fmt.Fprintf(&b, "==> %s <==\n", file)
+			}
+			for _, keyword := range extracted.Keywords {
+				b.WriteString(keyword.Text)
+				b.WriteString("\n")
+			}
+			_, err = io.WriteString(stdout, b.String())
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, "tldr:", err)
+			return 1
+		}
+	}
+	return 0
+}
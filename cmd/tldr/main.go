@@ -0,0 +1,302 @@
+// Command tldr summarizes texts from files or the standard input.
+//
+// Usage:
+//
+//	tldr [flags] [file ...]
+//	tldr keywords [flags] [file ...]
+//	tldr serve [flags]
+//
+// With no file, or when a file is -, it reads the standard input.
+// The keywords command prints the keywords of the files instead of their summary.
+// The serve command serves summaries over HTTP, see the server package.
+// Run tldr -h and tldr serve -h for the flags.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/didasy/tldr"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// options holds the flags not mapped directly to the settings of the bag
+type options struct {
+	sentences  int
+	keywords   int
+	format     string
+	stemmer    string
+	preprocess bool
+	version    bool
+	config     string
+	preset     string
+}
+
+// run runs the command with args and returns its exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "serve" {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return serve(ctx, args[1:], stdout, stderr)
+	}
+	if len(args) > 0 && args[0] == "keywords" {
+		return keywords(args[1:], stdin, stdout, stderr)
+	}
+
+	bag, opts, fs, err := parseFlags(args, stderr, newFlagSet)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if opts.version {
+		fmt.Fprintln(stdout, "tldr", tldr.VERSION)
+		return 0
+	}
+	switch opts.format {
+	case "text", "json", "markdown", "paragraph", "bullets", "html":
+	default:
+		fmt.Fprintf(stderr, "tldr: unknown format %q\n", opts.format)
+		return 2
+	}
+	if opts.sentences < 1 {
+		fmt.Fprintln(stderr, "tldr: -n must be at least 1")
+		return 2
+	}
+	if err := configure(bag, opts); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for i, file := range files {
+		summary, text, err := summarizeFile(bag, opts, file, stdin)
+		if err != nil {
+			fmt.Fprintln(stderr, "tldr:", err)
+			return 1
+		}
+		if len(files) == 1 {
+			file = ""
+		}
+		if err := write(stdout, opts.format, file, text, summary, i == 0); err != nil {
+			fmt.Fprintln(stderr, "tldr:", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseFlags parses args with the flags defined by define into a new bag.
+// The configuration file and the preset of the flags are applied first,
+// then args are parsed again with their settings as defaults, so the other flags override them.
+// Errors are printed to output.
+func parseFlags(args []string, output io.Writer, define func(bag *tldr.Bag, opts *options, output io.Writer) *flag.FlagSet) (*tldr.Bag, *options, *flag.FlagSet, error) {
+	bag, opts := tldr.New(), &options{}
+	if define(bag, opts, io.Discard).Parse(args) == nil && (opts.config != "" || opts.preset != "") {
+		config := &tldr.Config{}
+		if opts.config != "" {
+			loaded, err := tldr.LoadConfig(opts.config)
+			if err != nil {
+				fmt.Fprintln(output, err)
+				return nil, nil, nil, err
+			}
+			config = loaded
+		}
+		if opts.preset != "" {
+			config.Preset = opts.preset
+		}
+		bag = tldr.New()
+		if err := bag.ApplyConfig(config); err != nil {
+			fmt.Fprintln(output, err)
+			return nil, nil, nil, err
+		}
+	}
+
+	opts = &options{}
+	fs := define(bag, opts, output)
+	return bag, opts, fs, fs.Parse(args)
+}
+
+// newFlagSet defines the flags of the command
+func newFlagSet(bag *tldr.Bag, opts *options, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("tldr", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: tldr [flags] [file ...]")
+		fmt.Fprintln(fs.Output(), "       tldr keywords [flags] [file ...]")
+		fmt.Fprintln(fs.Output(), "       tldr serve [flags]")
+		fmt.Fprintln(fs.Output(), "Summarizes the files, or the standard input if there is none or a file is -.")
+		fs.PrintDefaults()
+	}
+
+	fs.IntVar(&opts.sentences, "n", 3, "number of sentences of the summary")
+	fs.StringVar(&opts.format, "format", "text", `output format: "text", "json", "markdown", "paragraph", "bullets", or "html" highlighting the summary in the text`)
+	fs.BoolVar(&opts.version, "version", false, "print the version and exit")
+	addBagFlags(fs, bag, opts)
+	return fs
+}
+
+// addBagFlags defines a flag for every setting of the bag, with its current value as default
+func addBagFlags(fs *flag.FlagSet, bag *tldr.Bag, opts *options) {
+	fs.StringVar(&opts.config, "config", "", "JSON, YAML or TOML file of settings, overridden by the other flags")
+	fs.StringVar(&opts.preset, "preset", "", "preset of settings, overridden by the other flags: "+strings.Join(tldr.PresetNames(), ", ")+", or one of the configuration file")
+	fs.StringVar(&opts.stemmer, "stemmer", "", "stemmer to use instead of the one of the language: english, german, spanish or indonesian")
+	fs.BoolVar(&opts.preprocess, "preprocess", false, "normalize unicode, whitespace and quotes, and join hyphenated words before summarizing")
+
+	fs.StringVar(&bag.Algorithm, "algorithm", bag.Algorithm, `ranking algorithm: "pagerank" or "centrality"`)
+	fs.StringVar(&bag.Weighing, "weighing", bag.Weighing, `edge weighing: "hamming" or "jaccard"`)
+	fs.StringVar(&bag.KeywordAlgorithm, "keyword-algorithm", bag.KeywordAlgorithm, `keyword extraction algorithm: "textrank", or "yake" for short texts`)
+	fs.Float64Var(&bag.Damping, "damping", bag.Damping, "damping factor of pagerank")
+	fs.Float64Var(&bag.Tolerance, "tolerance", bag.Tolerance, "tolerance of pagerank")
+	fs.Float64Var(&bag.Threshold, "threshold", bag.Threshold, "lowest weight of an edge between two sentences")
+	fs.Float64Var(&bag.SentencesDistanceThreshold, "distance", bag.SentencesDistanceThreshold, "sentences closer than this distance are duplicates")
+	fs.IntVar(&bag.MaxCharacters, "max-chars", bag.MaxCharacters, "maximum number of characters of the summary, 0 for no limit")
+	fs.StringVar(&bag.Truncation, "truncation", bag.Truncation, `handling of the sentence overflowing -max-chars: "cut", "word" at a word boundary, "drop", or "skip" to a shorter sentence`)
+	fs.StringVar(&bag.Ellipsis, "ellipsis", bag.Ellipsis, "appended to the sentences cut by -max-chars")
+	fs.StringVar(&bag.Separator, "separator", bag.Separator, "separator of the sentences counted in -max-chars")
+	fs.Float64Var(&bag.Ratio, "ratio", bag.Ratio, "share of the sentences of the text kept in the summary, between 0 and 1, overrides -n, 0 for none")
+	fs.IntVar(&bag.MaxWords, "max-words", bag.MaxWords, "maximum number of words of the summary, selecting the sentences with the highest total score, 0 for no limit")
+	fs.IntVar(&bag.MaxTokens, "max-tokens", bag.MaxTokens, "maximum number of estimated language model tokens of the summary, selecting the sentences with the highest total score, 0 for no limit")
+	fs.StringVar(&bag.Language, "language", bag.Language, `language of the text, "auto" to detect it, empty for none`)
+	fs.IntVar(&bag.WordNGrams, "word-ngrams", bag.WordNGrams, "longest word n-gram added to the sentence vectors")
+	fs.IntVar(&bag.CharNGrams, "char-ngrams", bag.CharNGrams, "length of the character n-grams added to the sentence vectors, 0 for none")
+	fs.Float64Var(&bag.HeadingWeight, "heading-weight", bag.HeadingWeight, "boost of the sentences sharing words with their headings")
+	fs.Float64Var(&bag.ReplyWeight, "reply-weight", bag.ReplyWeight, "boost of the chat messages with the most replies")
+	fs.Float64Var(&bag.ReactionWeight, "reaction-weight", bag.ReactionWeight, "boost of the chat messages with the most reactions")
+	fs.Float64Var(&bag.PositionWeight, "position-weight", bag.PositionWeight, "boost of the sentences opening a paragraph")
+}
+
+// configure checks the flags and applies the ones not mapped directly to the bag
+func configure(bag *tldr.Bag, opts *options) error {
+	// the settings mapped to the bag are applied again to check them,
+	// custom algorithms and weighings cannot be set from the command line, so they are rejected too
+	config := &tldr.Config{
+		Algorithm:        &bag.Algorithm,
+		Weighing:         &bag.Weighing,
+		Language:         &bag.Language,
+		Ratio:            &bag.Ratio,
+		MaxWords:         &bag.MaxWords,
+		MaxTokens:        &bag.MaxTokens,
+		Truncation:       &bag.Truncation,
+		KeywordAlgorithm: &bag.KeywordAlgorithm,
+	}
+	if opts.stemmer != "" {
+		config.Stemmer = &opts.stemmer
+	}
+	if opts.preprocess {
+		config.Preprocess = &opts.preprocess
+	}
+	return bag.ApplyConfig(config)
+}
+
+// summarizeFile summarizes the file, or stdin if the file is -, and returns the summary with the text of the file
+func summarizeFile(bag *tldr.Bag, opts *options, file string, stdin io.Reader) (*tldr.Summary, string, error) {
+	text, err := readFile(file, stdin)
+	if err != nil {
+		return nil, "", err
+	}
+	// an empty file would summarize the sentences of the last file again
+	bag.OriginalSentences = nil
+	summary, err := bag.SummarizeDetailed(text, opts.sentences)
+	return summary, text, err
+}
+
+// readFile reads the file, or stdin if the file is -
+func readFile(file string, stdin io.Reader) (string, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	return string(data), err
+}
+
+// jsonSummary is the JSON output of a summary
+type jsonSummary struct {
+	File      string           `json:"file,omitempty"`
+	Language  string           `json:"language,omitempty"`
+	Sentences []*tldr.Sentence `json:"sentences"`
+}
+
+// write prints the summary of the text of the file in format, file is empty when it is the only one
+func write(w io.Writer, format, file, text string, summary *tldr.Summary, first bool) error {
+	var err error
+	switch format {
+	case "json":
+		sentences := summary.Sentences
+		if sentences == nil {
+			sentences = []*tldr.Sentence{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		err = encoder.Encode(&jsonSummary{File: file, Language: summary.Language, Sentences: sentences})
+	case "markdown":
+		var b strings.Builder
+		if !first {
+			b.WriteString("\n")
+		}
+		if file != "" {
+			fmt.Fprintf(&b, "## %s\n\n", file)
+		}
+		for _, sentence := range summary.Sentences {
+			fmt.Fprintf(&b, "- %s\n", tldr.EscapeMarkdown(tldr.OneLine(sentence.Text)))
+		}
+		_, err = io.WriteString(w, b.String())
+	case "paragraph", "bullets":
+		var b strings.Builder
+		if !first {
+			b.WriteString("\n")
+		}
+		if file != "" {
+			fmt.Fprintf(&b, "==> %s <==\n", file)
+		}
+		if format == "paragraph" {
+			b.WriteString(summary.Paragraph())
+			b.WriteString("\n")
+		} else {
+			b.WriteString(summary.Bullets())
+		}
+		_, err = io.WriteString(w, b.String())
+	case "html":
+		var b strings.Builder
+		if file != "" {
+			fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(file))
+		}
+		b.WriteString(summary.HTML(text))
+		b.WriteString("\n")
+		_, err = io.WriteString(w, b.String())
+	default:
+		var b strings.Builder
+		if !first {
+			b.WriteString("\n")
+		}
+		if file != "" {
+			fmt.Fprintf(&b, "==> %s <==\n", file)
+		}
+		for _, sentence := range summary.Sentences {
+			b.WriteString(tldr.OneLine(sentence.Text))
+			b.WriteString("\n")
+		}
+		_, err = io.WriteString(w, b.String())
+	}
+	return err
+}
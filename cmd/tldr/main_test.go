@@ -0,0 +1,195 @@
+package main
+
+import (
+	"github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var _ = Describe("tldr", func() {
+	var stdout, stderr *bytes.Buffer
+	var sample string
+
+	BeforeEach(func() {
+		stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+		data, err := os.ReadFile("../../sample.txt")
+		Expect(err).To(BeNil())
+		sample = string(data)
+	})
+
+	It("Should summarize the standard input to one sentence per line", func() {
+		sums, err := tldr.New().Summarize(sample, 3)
+		Expect(err).To(BeNil())
+
+		Expect(run(nil, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		Expect(stderr.String()).To(BeEmpty())
+		lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+		Expect(lines).To(HaveLen(3))
+		for i, line := range lines {
+			Expect(line).To(Equal(strings.Join(strings.Fields(sums[i]), " ")))
+		}
+	})
+
+	It("Should pass the settings to the bag", func() {
+		bag := tldr.New()
+		bag.Algorithm = "centrality"
+		bag.Weighing = "jaccard"
+		bag.MaxCharacters = 200
+		sums, err := bag.Summarize(sample, 2)
+		Expect(err).To(BeNil())
+
+		args := []string{"-n", "2", "-algorithm", "centrality", "-weighing", "jaccard", "-max-chars", "200", "-format", "json", "-"}
+		Expect(run(args, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		summary := &jsonSummary{}
+		Expect(json.Unmarshal(stdout.Bytes(), summary)).To(Succeed())
+		Expect(summary.File).To(BeEmpty())
+		Expect(summary.Sentences).To(HaveLen(len(sums)))
+		for i, sentence := range summary.Sentences {
+			Expect(sentence.Text).To(Equal(sums[i]))
+		}
+	})
+
+	It("Should keep a share of the sentences with -ratio", func() {
+		bag := tldr.New()
+		_, err := bag.Summarize(sample, 1)
+		Expect(err).To(BeNil())
+		total := len(bag.OriginalSentences)
+
+		Expect(run([]string{"-ratio", "0.2", "-format", "json"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		summary := &jsonSummary{}
+		Expect(json.Unmarshal(stdout.Bytes(), summary)).To(Succeed())
+		Expect(len(summary.Sentences)).To(Equal((total*2 + 9) / 10))
+	})
+
+	It("Should fit the summary in -max-words", func() {
+		Expect(run([]string{"-n", "10", "-max-words", "50"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		Expect(stdout.String()).NotTo(BeEmpty())
+		Expect(len(strings.Fields(stdout.String()))).To(BeNumerically("<=", 50))
+		Expect(run([]string{"-max-tokens", "-1"}, strings.NewReader(sample), stdout, stderr)).To(Equal(2))
+	})
+
+	It("Should apply the preset and configuration file before the other flags", func() {
+		Expect(run([]string{"-preset", "short-review", "-n", "5"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		Expect(len(strings.Join(strings.Split(stdout.String(), "\n"), ""))).To(BeNumerically("<=", 280))
+
+		dir, err := os.MkdirTemp("", "tldr")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		config := filepath.Join(dir, "tldr.toml")
+		Expect(os.WriteFile(config, []byte("preset = \"short-review\"\nmax_characters = 100\n"), 0644)).To(Succeed())
+
+		stdout.Reset()
+		Expect(run([]string{"-config", config, "-format", "json"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		summary := &jsonSummary{}
+		Expect(json.Unmarshal(stdout.Bytes(), summary)).To(Succeed())
+		length := 0
+		for _, sentence := range summary.Sentences {
+			length += len([]rune(sentence.Text))
+		}
+		Expect(length).To(BeNumerically("<=", 100))
+		// the preset cuts the last sentence between words
+		Expect(strings.Contains(sample, summary.Sentences[len(summary.Sentences)-1].Text+" ")).To(BeTrue())
+
+		stdout.Reset()
+		Expect(run([]string{"-config", config, "-max-chars", "0", "-n", "2"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		Expect(len(stdout.String())).To(BeNumerically(">", 100))
+
+		Expect(run([]string{"-preset", "poetry"}, strings.NewReader(sample), stdout, stderr)).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring(`unknown preset "poetry"`))
+	})
+
+	It("Should print a paragraph, bullets, or the text highlighted in HTML", func() {
+		Expect(run([]string{"-n", "2", "-format", "paragraph"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		Expect(strings.Count(stdout.String(), "\n")).To(Equal(1))
+
+		stdout.Reset()
+		Expect(run([]string{"-n", "2", "-format", "bullets"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		Expect(strings.Count(stdout.String(), "• ")).To(Equal(2))
+
+		stdout.Reset()
+		Expect(run([]string{"-n", "2", "-format", "html"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		Expect(stdout.String()).To(HavePrefix("<p>"))
+		Expect(strings.Count(stdout.String(), `class="tldr-highlight" id="tldr-`)).To(Equal(2))
+	})
+
+	It("Should print the keywords of the text", func() {
+		expected, err := tldr.New().ExtractKeywords(sample, 5)
+		Expect(err).To(BeNil())
+
+		Expect(run([]string{"keywords", "-n", "5"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+		Expect(lines).To(HaveLen(5))
+		for i, line := range lines {
+			Expect(line).To(Equal(expected.Keywords[i].Text))
+		}
+
+		stdout.Reset()
+		Expect(run([]string{"keywords", "-format", "json", "-language", "auto"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		keywords := &jsonKeywords{}
+		Expect(json.Unmarshal(stdout.Bytes(), keywords)).To(Succeed())
+		Expect(keywords.Language).To(Equal("english"))
+		Expect(keywords.Keywords).To(HaveLen(10))
+
+		stdout.Reset()
+		bag := tldr.New()
+		bag.KeywordAlgorithm = tldr.KEYWORDS_YAKE
+		expected, err = bag.ExtractKeywords(sample, 3)
+		Expect(err).To(BeNil())
+		Expect(run([]string{"keywords", "-n", "3", "-keyword-algorithm", "yake"}, strings.NewReader(sample), stdout, stderr)).To(Equal(0))
+		Expect(stdout.String()).To(Equal(expected.Keywords[0].Text + "\n" + expected.Keywords[1].Text + "\n" + expected.Keywords[2].Text + "\n"))
+		Expect(run([]string{"keywords", "-keyword-algorithm", "rake"}, strings.NewReader(sample), stdout, stderr)).NotTo(Equal(0))
+
+		Expect(run([]string{"keywords", "-n", "0"}, strings.NewReader(sample), stdout, stderr)).To(Equal(2))
+	})
+
+	It("Should print a Markdown section for every file", func() {
+		args := []string{"-n", "2", "-format", "markdown", "../../sample.txt", "../../sample.txt"}
+		Expect(run(args, nil, stdout, stderr)).To(Equal(0))
+		output := stdout.String()
+		Expect(strings.Count(output, "## ../../sample.txt\n\n")).To(Equal(2))
+		Expect(strings.Count(output, "\n- ")).To(Equal(4))
+	})
+
+	It("Should print an empty summary for an empty file", func() {
+		dir, err := os.MkdirTemp("", "tldr")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		empty := filepath.Join(dir, "empty.txt")
+		Expect(os.WriteFile(empty, nil, 0644)).To(Succeed())
+
+		Expect(run([]string{"-n", "2", "../../sample.txt", empty}, nil, stdout, stderr)).To(Equal(0))
+		Expect(strings.HasSuffix(stdout.String(), "\n\n==> "+empty+" <==\n")).To(BeTrue())
+		Expect(strings.Count(stdout.String(), "\n")).To(Equal(5))
+	})
+
+	It("Should reject invalid settings", func() {
+		Expect(run([]string{"-algorithm", "random"}, strings.NewReader(sample), stdout, stderr)).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring(`unknown algorithm "random"`))
+		Expect(run([]string{"-ratio", "2"}, strings.NewReader(sample), stdout, stderr)).To(Equal(2))
+		Expect(run([]string{"-nope"}, strings.NewReader(sample), stdout, stderr)).To(Equal(2))
+		Expect(stdout.String()).To(BeEmpty())
+	})
+
+	It("Should fail on a missing file", func() {
+		Expect(run([]string{"missing.txt"}, nil, stdout, stderr)).To(Equal(1))
+		Expect(stderr.String()).To(HavePrefix("tldr: "))
+	})
+
+	It("Should serve until stopped", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(serve(ctx, []string{"-addr", "127.0.0.1:0", "-grpc-addr", "127.0.0.1:0", "-n", "2"}, stdout, stderr)).To(Equal(0))
+		Expect(stdout.String()).To(HavePrefix("tldr: serving on"))
+		Expect(stdout.String()).To(ContainSubstring("tldr: serving gRPC on"))
+		Expect(run([]string{"serve", "-weighing", "cosine"}, nil, stdout, stderr)).To(Equal(2))
+		Expect(run([]string{"serve", "file.txt"}, nil, stdout, stderr)).To(Equal(2))
+	})
+})
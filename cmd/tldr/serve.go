@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/didasy/tldr"
+	"github.com/didasy/tldr/grpcserver"
+	"github.com/didasy/tldr/server"
+)
+
+// serve runs the HTTP server until ctx is done and returns the exit code
+func serve(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	config := server.Config{}
+	grpcAddr := ""
+	defaults, opts, fs, err := parseFlags(args, stderr, func(bag *tldr.Bag, opts *options, output io.Writer) *flag.FlagSet {
+		fs := flag.NewFlagSet("tldr serve", flag.ContinueOnError)
+		fs.SetOutput(output)
+		fs.Usage = func() {
+			fmt.Fprintln(fs.Output(), "Usage: tldr serve [flags]")
+			fmt.Fprintln(fs.Output(), "Serves summaries and keywords over HTTP, and gRPC with -grpc-addr. The settings of the bag are the defaults of the requests.")
+			fs.PrintDefaults()
+		}
+		fs.IntVar(&opts.sentences, "n", server.DEFAULT_SENTENCES, "number of sentences of a summary when the request has none")
+		fs.IntVar(&opts.keywords, "keywords", server.DEFAULT_KEYWORDS, "number of keywords when the request has none")
+		fs.StringVar(&config.Addr, "addr", server.DEFAULT_ADDR, "address to listen on")
+		fs.StringVar(&grpcAddr, "grpc-addr", "", "address to serve the gRPC service on, empty for none")
+		fs.Int64Var(&config.MaxBodyBytes, "max-body", server.DEFAULT_MAX_BODY_BYTES, "largest request body accepted, in bytes")
+		fs.DurationVar(&config.Timeout, "timeout", server.DEFAULT_TIMEOUT, "longest time spent summarizing a request, its work goes on in the background once it times out")
+		fs.IntVar(&config.MaxConcurrent, "max-concurrent", 0, "largest number of texts summarized at once, timed out ones included, the number of CPUs if 0")
+		fs.DurationVar(&config.ShutdownTimeout, "shutdown-timeout", server.DEFAULT_SHUTDOWN_TIMEOUT, "longest time waited for the requests in progress when shutting down")
+		fs.DurationVar(&config.DrainDelay, "drain-delay", 0, "time still serving once not ready when shutting down, for the load balancers to notice")
+		addBagFlags(fs, bag, opts)
+		return fs
+	})
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "tldr: unexpected argument %q\n", fs.Arg(0))
+		return 2
+	}
+	if opts.sentences < 1 || opts.keywords < 1 {
+		fmt.Fprintln(stderr, "tldr: -n and -keywords must be at least 1")
+		return 2
+	}
+	if err := configure(defaults, opts); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	config.Sentences = opts.sentences
+	config.Keywords = opts.keywords
+	config.Ratio = defaults.Ratio
+	config.NewBag = func() *tldr.Bag {
+		// defaults is never used to summarize, so its copies share nothing but their settings
+		bag := *defaults
+		return &bag
+	}
+
+	// stop both servers as soon as one of them fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errs := make(chan error, 2)
+	servers := 1
+	fmt.Fprintln(stdout, "tldr: serving on", config.Addr)
+	httpServer := server.New(config)
+	go func() {
+		errs <- httpServer.ListenAndServe(ctx)
+	}()
+	if grpcAddr != "" {
+		servers++
+		fmt.Fprintln(stdout, "tldr: serving gRPC on", grpcAddr)
+		go func() {
+			// both servers take their slots from the HTTP one
+			listener, err := net.Listen("tcp", grpcAddr)
+			if err != nil {
+				errs <- err
+				return
+			}
+			errs <- grpcserver.NewFromServer(httpServer).Serve(ctx, listener)
+		}()
+	}
+
+	code := 0
+	for ; servers > 0; servers-- {
+		if err := <-errs; err != nil {
+			fmt.Fprintln(stderr, "tldr:", err)
+			code = 1
+			cancel()
+		}
+	}
+	return code
+}
@@ -0,0 +1,297 @@
+package tldr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds settings of a bag, as read from a JSON, YAML or TOML file.
+// Settings left out keep their current value.
+type Config struct {
+	Preset                     string             `json:"preset,omitempty" yaml:"preset,omitempty" toml:"preset,omitempty"` // applied before the other settings
+	Algorithm                  *string            `json:"algorithm,omitempty" yaml:"algorithm,omitempty" toml:"algorithm,omitempty"`
+	Weighing                   *string            `json:"weighing,omitempty" yaml:"weighing,omitempty" toml:"weighing,omitempty"`
+	Damping                    *float64           `json:"damping,omitempty" yaml:"damping,omitempty" toml:"damping,omitempty"`
+	Tolerance                  *float64           `json:"tolerance,omitempty" yaml:"tolerance,omitempty" toml:"tolerance,omitempty"`
+	Threshold                  *float64           `json:"threshold,omitempty" yaml:"threshold,omitempty" toml:"threshold,omitempty"`
+	SentencesDistanceThreshold *float64           `json:"sentences_distance_threshold,omitempty" yaml:"sentences_distance_threshold,omitempty" toml:"sentences_distance_threshold,omitempty"`
+	MaxCharacters              *int               `json:"max_characters,omitempty" yaml:"max_characters,omitempty" toml:"max_characters,omitempty"`
+	Ratio                      *float64           `json:"ratio,omitempty" yaml:"ratio,omitempty" toml:"ratio,omitempty"`
+	MaxWords                   *int               `json:"max_words,omitempty" yaml:"max_words,omitempty" toml:"max_words,omitempty"`
+	MaxTokens                  *int               `json:"max_tokens,omitempty" yaml:"max_tokens,omitempty" toml:"max_tokens,omitempty"`
+	Truncation                 *string            `json:"truncation,omitempty" yaml:"truncation,omitempty" toml:"truncation,omitempty"`
+	Ellipsis                   *string            `json:"ellipsis,omitempty" yaml:"ellipsis,omitempty" toml:"ellipsis,omitempty"`
+	Separator                  *string            `json:"separator,omitempty" yaml:"separator,omitempty" toml:"separator,omitempty"`
+	KeywordAlgorithm           *string            `json:"keyword_algorithm,omitempty" yaml:"keyword_algorithm,omitempty" toml:"keyword_algorithm,omitempty"`
+	Language                   *string            `json:"language,omitempty" yaml:"language,omitempty" toml:"language,omitempty"`
+	WordNGrams                 *int               `json:"word_ngrams,omitempty" yaml:"word_ngrams,omitempty" toml:"word_ngrams,omitempty"`
+	CharNGrams                 *int               `json:"char_ngrams,omitempty" yaml:"char_ngrams,omitempty" toml:"char_ngrams,omitempty"`
+	HeadingWeight              *float64           `json:"heading_weight,omitempty" yaml:"heading_weight,omitempty" toml:"heading_weight,omitempty"`
+	ReplyWeight                *float64           `json:"reply_weight,omitempty" yaml:"reply_weight,omitempty" toml:"reply_weight,omitempty"`
+	ReactionWeight             *float64           `json:"reaction_weight,omitempty" yaml:"reaction_weight,omitempty" toml:"reaction_weight,omitempty"`
+	PositionWeight             *float64           `json:"position_weight,omitempty" yaml:"position_weight,omitempty" toml:"position_weight,omitempty"`
+	Stemmer                    *string            `json:"stemmer,omitempty" yaml:"stemmer,omitempty" toml:"stemmer,omitempty"`          // name of a built in stemmer, empty for the one of the language
+	Preprocess                 *bool              `json:"preprocess,omitempty" yaml:"preprocess,omitempty" toml:"preprocess,omitempty"` // run DefaultPreprocessors, or none
+	Presets                    map[string]*Config `json:"presets,omitempty" yaml:"presets,omitempty" toml:"presets,omitempty"`          // presets added or overridden by the file
+}
+
+func stringSetting(s string) *string  { return &s }
+func floatSetting(f float64) *float64 { return &f }
+func intSetting(i int) *int           { return &i }
+func boolSetting(b bool) *bool        { return &b }
+
+// Presets holds the named configurations shipped with the package, change it to override them for every bag.
+// The weights of a preset only apply to the entry points reading the structure they boost.
+var Presets = map[string]*Config{
+	// news articles put the facts first, and quote the same facts in different words.
+	// The PositionWeight only applies to SummarizeDocument, not to Summarize, the command line or the servers.
+	"news": {
+		Algorithm:                  stringSetting("pagerank"),
+		Weighing:                   stringSetting("hamming"),
+		Damping:                    floatSetting(0.85),
+		Tolerance:                  floatSetting(0.0001),
+		Threshold:                  floatSetting(0.001),
+		SentencesDistanceThreshold: floatSetting(0.9),
+		Language:                   stringSetting("auto"),
+		PositionWeight:             floatSetting(0.3),
+		Preprocess:                 boolSetting(true),
+	},
+	// legal texts repeat long clauses differing by a few words, which must not be merged, for every entry point
+	"legal": {
+		Algorithm:                  stringSetting("pagerank"),
+		Weighing:                   stringSetting("jaccard"),
+		Damping:                    floatSetting(0.9),
+		Tolerance:                  floatSetting(0.00001),
+		Threshold:                  floatSetting(0.01),
+		SentencesDistanceThreshold: floatSetting(0.98),
+		Language:                   stringSetting("auto"),
+		WordNGrams:                 intSetting(2),
+		Preprocess:                 boolSetting(true),
+	},
+	// chat messages are short, misspelled and repetitive.
+	// The ReplyWeight and ReactionWeight only apply to SummarizeChat, not to Summarize, the command line or the servers.
+	"chat": {
+		Algorithm:                  stringSetting("centrality"),
+		Weighing:                   stringSetting("jaccard"),
+		Threshold:                  floatSetting(0),
+		SentencesDistanceThreshold: floatSetting(0.8),
+		Language:                   stringSetting("auto"),
+		CharNGrams:                 intSetting(3),
+		ReplyWeight:                floatSetting(0.3),
+		ReactionWeight:             floatSetting(0.2),
+	},
+	// reviews are summarized to a line fitting in a card, cut between words, for every entry point
+	"short-review": {
+		Algorithm:                  stringSetting("centrality"),
+		Weighing:                   stringSetting("jaccard"),
+		Threshold:                  floatSetting(0),
+		SentencesDistanceThreshold: floatSetting(0.9),
+		MaxCharacters:              intSetting(280),
+		Truncation:                 stringSetting(TRUNCATION_WORD),
+		Language:                   stringSetting("auto"),
+	},
+}
+
+// LoadConfig reads a configuration file, in JSON, YAML or TOML depending on its extension
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := ParseConfig(data, strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return nil, fmt.Errorf("tldr: %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseConfig parses a configuration in format, "json", "yaml" or "toml". Unknown settings are errors.
+func ParseConfig(data []byte, format string) (*Config, error) {
+	config := &Config{}
+	switch strings.ToLower(format) {
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(config); err != nil {
+			return nil, err
+		}
+	case "yaml", "yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && err != io.EOF {
+			return nil, err
+		}
+	case "toml":
+		meta, err := toml.Decode(string(data), config)
+		if err != nil {
+			return nil, err
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("unknown setting %q", undecoded[0].String())
+		}
+	default:
+		return nil, fmt.Errorf("unknown configuration format %q", format)
+	}
+	return config, nil
+}
+
+// PresetNames returns the names of the presets shipped with the package, sorted
+func PresetNames() []string {
+	names := make([]string, 0, len(Presets))
+	for name := range Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyPreset sets the settings of the named preset of Presets
+func (bag *Bag) ApplyPreset(name string) error {
+	return bag.ApplyConfig(&Config{Preset: name})
+}
+
+// ApplyConfig sets the settings of config, after the ones of its preset.
+// The preset is looked up in the presets of config, then in Presets.
+// Nothing is set if a setting is invalid.
+func (bag *Bag) ApplyConfig(config *Config) error {
+	configs := []*Config{config}
+	for seen := map[string]bool{}; configs[0].Preset != ""; {
+		name := configs[0].Preset
+		if seen[name] {
+			return fmt.Errorf("tldr: preset %q includes itself", name)
+		}
+		seen[name] = true
+		preset, exists := config.Presets[name]
+		if !exists {
+			preset, exists = Presets[name]
+		}
+		if !exists {
+			return fmt.Errorf("tldr: unknown preset %q", name)
+		}
+		configs = append([]*Config{preset}, configs...)
+	}
+
+	applied := *bag
+	for _, c := range configs {
+		if err := applied.applyConfig(c); err != nil {
+			return err
+		}
+	}
+	*bag = applied
+	return nil
+}
+
+// applyConfig sets the settings of config, without its preset
+func (bag *Bag) applyConfig(config *Config) error {
+	if config.Algorithm != nil {
+		switch *config.Algorithm {
+		case "pagerank", "centrality":
+		case "custom":
+			if bag.customAlgorithm == nil {
+				return fmt.Errorf("tldr: custom algorithm not set")
+			}
+		default:
+			return fmt.Errorf("tldr: unknown algorithm %q", *config.Algorithm)
+		}
+		bag.Algorithm = *config.Algorithm
+	}
+	if config.Weighing != nil {
+		switch *config.Weighing {
+		case "hamming", "jaccard":
+		case "custom":
+			if bag.customWeighing == nil {
+				return fmt.Errorf("tldr: custom weighing not set")
+			}
+		default:
+			return fmt.Errorf("tldr: unknown weighing %q", *config.Weighing)
+		}
+		bag.Weighing = *config.Weighing
+	}
+	if config.Language != nil {
+		if language := *config.Language; language != "" && language != "auto" && LookupLanguage(language) == nil {
+			return fmt.Errorf("tldr: unknown language %q", language)
+		}
+		bag.Language = *config.Language
+	}
+	if config.Stemmer != nil {
+		stemmer := Stemmer(*config.Stemmer)
+		if stemmer == nil && *config.Stemmer != "" {
+			return fmt.Errorf("tldr: unknown stemmer %q", *config.Stemmer)
+		}
+		bag.stemmer = stemmer
+	}
+	if config.Ratio != nil && (*config.Ratio < 0 || *config.Ratio > 1) {
+		return fmt.Errorf("tldr: ratio must be between 0 and 1")
+	}
+	if config.MaxWords != nil && *config.MaxWords < 0 {
+		return fmt.Errorf("tldr: max words must not be negative")
+	}
+	if config.MaxTokens != nil && *config.MaxTokens < 0 {
+		return fmt.Errorf("tldr: max tokens must not be negative")
+	}
+	if config.KeywordAlgorithm != nil {
+		switch *config.KeywordAlgorithm {
+		case KEYWORDS_TEXTRANK, KEYWORDS_YAKE:
+		default:
+			return fmt.Errorf("tldr: unknown keyword algorithm %q", *config.KeywordAlgorithm)
+		}
+		bag.KeywordAlgorithm = *config.KeywordAlgorithm
+	}
+	if config.Truncation != nil {
+		if err := checkTruncation(*config.Truncation); err != nil {
+			return err
+		}
+		bag.Truncation = *config.Truncation
+	}
+	if config.Preprocess != nil {
+		bag.preprocessors = nil
+		if *config.Preprocess {
+			bag.preprocessors = DefaultPreprocessors
+		}
+	}
+
+	setFloat(&bag.Damping, config.Damping)
+	setFloat(&bag.Tolerance, config.Tolerance)
+	setFloat(&bag.Threshold, config.Threshold)
+	setFloat(&bag.SentencesDistanceThreshold, config.SentencesDistanceThreshold)
+	setInt(&bag.MaxCharacters, config.MaxCharacters)
+	setFloat(&bag.Ratio, config.Ratio)
+	setInt(&bag.MaxWords, config.MaxWords)
+	setInt(&bag.MaxTokens, config.MaxTokens)
+	setString(&bag.Ellipsis, config.Ellipsis)
+	setString(&bag.Separator, config.Separator)
+	setInt(&bag.WordNGrams, config.WordNGrams)
+	setInt(&bag.CharNGrams, config.CharNGrams)
+	setFloat(&bag.HeadingWeight, config.HeadingWeight)
+	setFloat(&bag.ReplyWeight, config.ReplyWeight)
+	setFloat(&bag.ReactionWeight, config.ReactionWeight)
+	setFloat(&bag.PositionWeight, config.PositionWeight)
+	return nil
+}
+
+func setFloat(setting *float64, value *float64) {
+	if value != nil {
+		*setting = *value
+	}
+}
+
+func setString(setting *string, value *string) {
+	if value != nil {
+		*setting = *value
+	}
+}
+
+func setInt(setting *int, value *int) {
+	if value != nil {
+		*setting = *value
+	}
+}
@@ -0,0 +1,125 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Config", func() {
+	files := map[string]string{
+		"json": `{"preset": "news", "damping": 0.7, "max_characters": 500, "stemmer": "english"}`,
+		"yaml": "preset: news\ndamping: 0.7\nmax_characters: 500\nstemmer: english\n",
+		"toml": "preset = \"news\"\ndamping = 0.7\nmax_characters = 500\nstemmer = \"english\"\n",
+	}
+
+	It("Should read the same settings from every format", func() {
+		for format, data := range files {
+			config, err := ParseConfig([]byte(data), format)
+			Expect(err).To(BeNil(), format)
+
+			bag := New()
+			Expect(bag.ApplyConfig(config)).To(Succeed(), format)
+			Expect(bag.Damping).To(Equal(0.7), format)
+			Expect(bag.MaxCharacters).To(Equal(500), format)
+			Expect(bag.SentencesDistanceThreshold).To(Equal(0.9), format)
+			Expect(bag.PositionWeight).To(Equal(0.3), format)
+			Expect(bag.Language).To(Equal("auto"), format)
+			Expect(bag.Tolerance).To(Equal(DEFAULT_TOLERANCE), format)
+		}
+	})
+
+	It("Should load a file by its extension", func() {
+		dir, err := os.MkdirTemp("", "tldr")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "tldr.yml")
+		Expect(os.WriteFile(path, []byte(files["yaml"]), 0644)).To(Succeed())
+		config, err := LoadConfig(path)
+		Expect(err).To(BeNil())
+		Expect(*config.Damping).To(Equal(0.7))
+
+		path = filepath.Join(dir, "tldr.ini")
+		Expect(os.WriteFile(path, []byte(files["toml"]), 0644)).To(Succeed())
+		_, err = LoadConfig(path)
+		Expect(err).To(MatchError(ContainSubstring(`unknown configuration format "ini"`)))
+	})
+
+	It("Should reject unknown settings", func() {
+		for format, data := range map[string]string{
+			"json": `{"dampin": 0.7}`,
+			"yaml": "dampin: 0.7\n",
+			"toml": "dampin = 0.7\n",
+		} {
+			_, err := ParseConfig([]byte(data), format)
+			Expect(err).NotTo(BeNil(), format)
+		}
+	})
+
+	It("Should apply every shipped preset", func() {
+		Expect(PresetNames()).To(Equal([]string{"chat", "legal", "news", "short-review"}))
+		for _, name := range PresetNames() {
+			bag := New()
+			Expect(bag.ApplyPreset(name)).To(Succeed(), name)
+			sums, err := bag.Summarize(text, 2)
+			Expect(err).To(BeNil(), name)
+			Expect(sums).NotTo(BeEmpty(), name)
+		}
+
+		bag := New()
+		Expect(bag.ApplyPreset("short-review")).To(Succeed())
+		Expect(bag.MaxCharacters).To(Equal(280))
+		Expect(bag.Truncation).To(Equal(TRUNCATION_WORD))
+		Expect(bag.Algorithm).To(Equal("centrality"))
+	})
+
+	It("Should let a file add and override presets", func() {
+		config, err := ParseConfig([]byte(`
+preset: mine
+presets:
+  mine:
+    preset: chat
+    threshold: 0.05
+  chat:
+    algorithm: pagerank
+`), "yaml")
+		Expect(err).To(BeNil())
+
+		bag := New()
+		Expect(bag.ApplyConfig(config)).To(Succeed())
+		Expect(bag.Threshold).To(Equal(0.05))
+		Expect(bag.Algorithm).To(Equal("pagerank"))
+		Expect(bag.CharNGrams).To(Equal(DEFAULT_CHAR_NGRAMS))
+	})
+
+	It("Should change nothing when a setting is invalid", func() {
+		bag := New()
+		algorithm := "centrality"
+		weighing := "cosine"
+		err := bag.ApplyConfig(&Config{Algorithm: &algorithm, Weighing: &weighing})
+		Expect(err).To(MatchError(`tldr: unknown weighing "cosine"`))
+		Expect(bag.Algorithm).To(Equal(DEFAULT_ALGORITHM))
+
+		Expect(bag.ApplyPreset("poetry")).To(MatchError(`tldr: unknown preset "poetry"`))
+		Expect(bag.ApplyConfig(&Config{Preset: "a", Presets: map[string]*Config{"a": {Preset: "a"}}})).NotTo(Succeed())
+	})
+
+	It("Should set the length limits", func() {
+		config, err := ParseConfig([]byte("ratio: 0.25\nmax_words: 100\nmax_tokens: 150\n"), "yaml")
+		Expect(err).To(BeNil())
+		bag := New()
+		Expect(bag.ApplyConfig(config)).To(Succeed())
+		Expect(bag.Ratio).To(Equal(0.25))
+		Expect(bag.MaxWords).To(Equal(100))
+		Expect(bag.MaxTokens).To(Equal(150))
+
+		ratio := 1.5
+		Expect(bag.ApplyConfig(&Config{Ratio: &ratio})).To(MatchError("tldr: ratio must be between 0 and 1"))
+		Expect(bag.Ratio).To(Equal(0.25))
+	})
+})
@@ -0,0 +1,146 @@
+package tldr
+
+import (
+	"strings"
+)
+
+// Document is a text structured in sections of paragraphs
+type Document struct {
+	Title    string
+	Sections []*Section
+}
+
+// Section is a section of a document, holding paragraphs and then subsections
+type Section struct {
+	Title      string
+	Paragraphs []string
+	Sections   []*Section
+}
+
+// DocumentSummary is the result of summarizing a document
+type DocumentSummary struct {
+	Title     string
+	Language  string
+	Sentences []*DocumentSentence // selected sentences, in document order
+}
+
+// DocumentSentence is a sentence selected from a document, along with where it came from.
+// Its Start and End are byte offsets in its paragraph.
+type DocumentSentence struct {
+	*Sentence
+	Section   *Section
+	Path      []string // titles of the sections holding the sentence, outermost first
+	Paragraph int      // index of the paragraph in the section
+	Position  int      // index of the sentence in the paragraph
+}
+
+// NewDocument builds a document of one untitled section from a text, with paragraphs separated by blank lines
+func NewDocument(title, text string) *Document {
+	section := &Section{}
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			section.Paragraphs = append(section.Paragraphs, paragraph)
+		}
+	}
+	return &Document{
+		Title:    title,
+		Sections: []*Section{section},
+	}
+}
+
+// documentSentence is where a sentence of a document came from
+type documentSentence struct {
+	section   *Section
+	path      []string
+	paragraph int
+	position  int
+	span      [2]int
+}
+
+// SummarizeDocument summarizes a document to num sentences, paragraphs end sentences.
+// When PositionWeight is set, sentences opening their paragraph rank higher, and when HeadingWeight is set,
+// sentences sharing words with the titles of the document and their sections rank higher.
+func (bag *Bag) SummarizeDocument(doc *Document, num int) (*DocumentSummary, error) {
+	summary := &DocumentSummary{
+		Title: doc.Title,
+	}
+
+	var paragraphs []*PreprocessedText
+	var origins []documentSentence
+	var texts []string
+	var walk func(sections []*Section, path []string)
+	walk = func(sections []*Section, path []string) {
+		for _, section := range sections {
+			sectionPath := path
+			if section.Title != "" {
+				sectionPath = append(append([]string(nil), path...), section.Title)
+			}
+			for i, paragraph := range section.Paragraphs {
+				pre := Preprocess(paragraph, bag.preprocessors...)
+				paragraphs = append(paragraphs, pre)
+				texts = append(texts, pre.Text)
+				origins = append(origins, documentSentence{section: section, path: sectionPath, paragraph: i})
+			}
+			walk(section.Sections, sectionPath)
+		}
+	}
+	walk(doc.Sections, nil)
+
+	bag.resolveLanguage(strings.Join(texts, "\n\n"))
+	summary.Language = bag.languageName()
+
+	var sentences []string
+	var from []documentSentence
+	for i, pre := range paragraphs {
+		at := 0
+		for position, sentence := range bag.splitBlock(pre.Text) {
+			origin := origins[i]
+			origin.position = position
+			origin.span = [2]int{-1, -1}
+			if start := strings.Index(pre.Text[at:], sentence); start >= 0 {
+				start += at
+				at = start + len(sentence)
+				origin.span = [2]int{pre.OriginalOffset(start), pre.OriginalOffset(at)}
+			}
+			sentences = append(sentences, sentence)
+			from = append(from, origin)
+		}
+	}
+
+	var boosts []float64
+	if bag.PositionWeight != 0 || bag.HeadingWeight != 0 {
+		boosts = make([]float64, len(sentences))
+		if bag.HeadingWeight != 0 {
+			titles := make([][]string, len(sentences))
+			owners := make([]int, len(sentences))
+			for i, origin := range from {
+				titles[i] = append([]string{doc.Title}, origin.path...)
+				owners[i] = i
+			}
+			boosts = bag.headingBoosts(sentences, owners, titles)
+		}
+		for i, origin := range from {
+			if origin.position == 0 {
+				boosts[i] += bag.PositionWeight
+			}
+		}
+	}
+
+	idx, err := bag.summarizeSentences(nil, sentences, boosts, num)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sentence := range bag.summarySentences(idx) {
+		origin := from[sentence.Index]
+		sentence.Start, sentence.End = origin.span[0], origin.span[1]
+		summary.Sentences = append(summary.Sentences, &DocumentSentence{
+			Sentence:  sentence,
+			Section:   origin.section,
+			Path:      origin.path,
+			Paragraph: origin.paragraph,
+			Position:  origin.position,
+		})
+	}
+	return summary, nil
+}
@@ -0,0 +1,106 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Documents", func() {
+	document := func() *Document {
+		return &Document{
+			Title: "Garden guide",
+			Sections: []*Section{
+				{
+					Title: "Soil",
+					Paragraphs: []string{
+						"Good soil keeps water and air for the roots. Most plants like soil rich in compost",
+						"Test the soil every spring. Add compost when the soil is poor.",
+					},
+					Sections: []*Section{
+						{
+							Title:      "Watering",
+							Paragraphs: []string{"Water the plants early in the morning. Roots take water best before the heat of the day."},
+						},
+					},
+				},
+				{
+					Title:      "Pests",
+					Paragraphs: []string{"Snails eat young leaves at night. Pick the snails by hand after rain."},
+				},
+			},
+		}
+	}
+
+	Describe("NewDocument()", func() {
+		It("Should split the paragraphs at blank lines", func() {
+			doc := NewDocument("Title", "First paragraph.\nStill first.\n\n\nSecond paragraph.\n")
+			Expect(doc.Sections).To(HaveLen(1))
+			Expect(doc.Sections[0].Paragraphs).To(Equal([]string{"First paragraph.\nStill first.", "Second paragraph."}))
+		})
+	})
+
+	Describe("SummarizeDocument()", func() {
+		It("Should report the section, paragraph and position of the sentences", func() {
+			doc := document()
+			summary, err := New().SummarizeDocument(doc, 8)
+			Expect(err).To(BeNil())
+			Expect(summary.Title).To(Equal("Garden guide"))
+			Expect(summary.Sentences).To(HaveLen(8))
+
+			unterminated := summary.Sentences[1]
+			Expect(unterminated.Text).To(Equal("Most plants like soil rich in compost"))
+			Expect(unterminated.Path).To(Equal([]string{"Soil"}))
+			Expect(unterminated.Paragraph).To(Equal(0))
+			Expect(unterminated.Position).To(Equal(1))
+
+			watering := summary.Sentences[5]
+			Expect(watering.Path).To(Equal([]string{"Soil", "Watering"}))
+			Expect(watering.Section).To(BeIdenticalTo(doc.Sections[0].Sections[0]))
+			Expect(watering.Section.Paragraphs[watering.Paragraph][watering.Start:watering.End]).To(Equal(watering.Text))
+		})
+
+		It("Should rank sentences opening paragraphs higher", func() {
+			bag := New()
+			bag.Algorithm = "custom"
+			bag.SetCustomAlgorithm(func(edges []*Edge) []int {
+				return []int{1, 3, 5, 7, 0, 2, 4, 6}
+			})
+			summary, err := bag.SummarizeDocument(document(), 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences[0].Position).To(Equal(1))
+
+			bag.PositionWeight = 2
+			summary, err = bag.SummarizeDocument(document(), 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences[0].Position).To(Equal(0))
+
+			// the other sentences are not boosted at all
+			bag.SetCustomAlgorithm(func(edges []*Edge) []int {
+				return []int{1, 0, 3, 2, 5, 4, 7, 6}
+			})
+			bag.PositionWeight = 0.2
+			summary, err = bag.SummarizeDocument(document(), 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences[0].Index).To(Equal(0))
+		})
+
+		It("Should rank sentences sharing words with their section titles higher", func() {
+			bag := New()
+			bag.Language = "english"
+			bag.Algorithm = "custom"
+			bag.SetCustomAlgorithm(func(edges []*Edge) []int {
+				return []int{7, 6, 5, 4, 3, 2, 1, 0}
+			})
+			summary, err := bag.SummarizeDocument(document(), 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences[0].Text).To(Equal("Pick the snails by hand after rain."))
+
+			bag.HeadingWeight = 4
+			summary, err = bag.SummarizeDocument(document(), 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences[0].Text).To(Equal("Add compost when the soil is poor."))
+		})
+	})
+})
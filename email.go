@@ -0,0 +1,359 @@
+package tldr
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// EmailMessage is a message of an email thread, keeping only the text its author wrote
+type EmailMessage struct {
+	MessageID string
+	From      string // example: "Jane Doe <jane@example.com>"
+	Subject   string
+	Date      time.Time
+	Body      string // without quoted replies, forwarded messages, signature and disclaimers
+}
+
+// EmailSummary is the result of summarizing an email or a thread of them
+type EmailSummary struct {
+	Subject   string // subject of the first message, without Re: and Fwd: prefixes
+	Language  string
+	Sentences []*EmailSentence // selected sentences, in the order of the messages
+}
+
+// EmailSentence is a sentence selected from an email thread, along with the message it came from.
+// Its Start and End are byte offsets in the Body of the message.
+type EmailSentence struct {
+	*Sentence
+	Message *EmailMessage
+}
+
+// Lines starting the quoted history of a reply or a forwarded message, signatures and disclaimers
+var (
+	replyHeader       = regexp.MustCompile(`(?im)^[ \t]*(?:On\s[^\n]{1,200}?(?:\n[^\n]{1,200}?)?\s+wrote:[ \t]*$|-{2,}\s*(?:Original Message|Forwarded message)\s*-{2,}|Begin forwarded message:|From:[ \t]*\S[^\n]*\n(?:[ \t]*(?:Sent|Date|To|Cc|Subject):[^\n]*\n?){2,})`)
+	signatureStart    = regexp.MustCompile(`(?m)^(?:-- ?|__+|Sent from my [^\n]+|Get Outlook for [^\n]+)$`)
+	signOff           = regexp.MustCompile(`(?i)^(?:best|best regards|kind regards|warm regards|warmly|regards|many thanks|thanks|thank you|thanks again|cheers|sincerely|yours truly|all the best|br|talk soon|see you)[,.!]?$`)
+	disclaimer        = regexp.MustCompile(`(?i)intended (?:solely )?(?:only )?(?:for the )?(?:use of the )?(?:named )?(?:addressee|recipient)|received this (?:e-?mail|message|communication) in error|confidentiality notice|this (?:e-?mail|message)(?: and any (?:attachments|files)[^.]*?)? (?:is|are|may be|may contain|contains?) (?:strictly )?(?:confidential|privileged)|unsubscribe|you are receiving this|do not reply to this (?:e-?mail|message)`)
+	subjectPrefix     = regexp.MustCompile(`(?i)^\s*(?:(?:re|fw|fwd|aw|sv|wg)(?:\[\d+\])?:\s*)+`)
+	mboxSeparator     = regexp.MustCompile(`^From \S+`)
+	blankLines        = regexp.MustCompile(`\n{3,}`)
+	quotedHTMLClasses = regexp.MustCompile(`(?i)gmail_quote|gmail_signature|yahoo_quoted|moz-cite-prefix|moz-signature|OutlookMessageHeader|divRplyFwdMsg|appendonsend|x_divRplyFwdMsg`)
+)
+
+// ParseEmail reads an RFC 5322 message. The body is the first text/plain part,
+// or the text of the first text/html part if there is none, cleaned up by CleanEmailBody.
+func ParseEmail(r io.Reader) (*EmailMessage, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("tldr: reading email: %w", err)
+	}
+
+	decoder := &mime.WordDecoder{CharsetReader: charsetReader}
+	message := &EmailMessage{
+		MessageID: strings.Trim(msg.Header.Get("Message-Id"), "<> "),
+	}
+	if subject, err := decoder.DecodeHeader(msg.Header.Get("Subject")); err == nil {
+		message.Subject = subject
+	} else {
+		message.Subject = msg.Header.Get("Subject")
+	}
+	if from := msg.Header.Get("From"); from != "" {
+		parser := &mail.AddressParser{WordDecoder: decoder}
+		if address, err := parser.Parse(from); err == nil {
+			message.From = address.Address
+			if address.Name != "" {
+				message.From = fmt.Sprintf("%s <%s>", address.Name, address.Address)
+			}
+		} else {
+			message.From = from
+		}
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		message.Date = date
+	}
+
+	var plain, rich string
+	if err := readEmailPart(textproto.MIMEHeader(msg.Header), msg.Body, &plain, &rich); err != nil {
+		return nil, err
+	}
+	if plain == "" && rich != "" {
+		plain, err = emailHTMLText(rich)
+		if err != nil {
+			return nil, err
+		}
+	}
+	message.Body = CleanEmailBody(plain)
+	return message, nil
+}
+
+// ParseMbox reads every message of an mbox file, see ParseEmail
+func ParseMbox(r io.Reader) ([]*EmailMessage, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	var messages []*EmailMessage
+	var raw bytes.Buffer
+	flush := func() error {
+		if strings.TrimSpace(raw.String()) == "" {
+			return nil
+		}
+		message, err := ParseEmail(bytes.NewReader(raw.Bytes()))
+		if err != nil {
+			return fmt.Errorf("tldr: message %d: %w", len(messages)+1, err)
+		}
+		messages = append(messages, message)
+		raw.Reset()
+		return nil
+	}
+
+	blank := true
+	for scanner.Scan() {
+		line := scanner.Text()
+		if blank && mboxSeparator.MatchString(line) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		blank = strings.TrimSpace(line) == ""
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") && strings.HasPrefix(line, ">") {
+			line = line[1:]
+		}
+		raw.WriteString(line)
+		raw.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("tldr: reading mbox: %w", err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// readEmailPart walks the MIME parts of a message, keeping the first text/plain and text/html parts
+// that are not attachments
+func readEmailPart(header textproto.MIMEHeader, body io.Reader, plain, rich *string) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		parts := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := parts.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("tldr: reading email part: %w", err)
+			}
+			if err := readEmailPart(part.Header, part, plain, rich); err != nil {
+				return err
+			}
+		}
+	}
+
+	if disposition, _, _ := mime.ParseMediaType(header.Get("Content-Disposition")); disposition == "attachment" {
+		return nil
+	}
+	if (mediaType != "text/plain" || *plain != "") && (mediaType != "text/html" || *rich != "") {
+		return nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	body, err = charsetReader(params["charset"], body)
+	if err != nil {
+		return err
+	}
+	text, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("tldr: reading email part: %w", err)
+	}
+
+	if mediaType == "text/plain" {
+		*plain = string(text)
+	} else {
+		*rich = string(text)
+	}
+	return nil
+}
+
+// charsetReader decodes text in the charset to UTF-8, unknown charsets are read as they are
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "utf8", "us-ascii":
+		return input, nil
+	}
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return input, nil
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
+
+// emailHTMLText turns an HTML email into plain text, leaving out quoted replies and blockquotes.
+// Block elements and line breaks end lines, paragraphs are separated by blank lines.
+func emailHTMLText(source string) (string, error) {
+	root, err := html.Parse(strings.NewReader(source))
+	if err != nil {
+		return "", fmt.Errorf("tldr: parsing email html: %w", err)
+	}
+
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(collapseSpaces(n.Data))
+			if strings.HasSuffix(n.Data, " ") || strings.HasSuffix(n.Data, "\n") {
+				b.WriteString(" ")
+			}
+			return
+		case html.ElementNode:
+			switch n.DataAtom {
+			case atom.Head, atom.Script, atom.Style, atom.Blockquote, atom.Title:
+				return
+			case atom.Br:
+				b.WriteString("\n")
+				return
+			}
+			if quotedHTMLClasses.MatchString(attr(n, "class") + " " + attr(n, "id")) {
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && blockElements[n.DataAtom] {
+			b.WriteString("\n\n")
+		}
+	}
+	walk(root)
+
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")), nil
+}
+
+// CleanEmailBody removes from the plain text body of an email the quoted history of replies and forwards,
+// the lines quoted with >, the signature and the paragraphs of legal disclaimers and mailing list footers
+func CleanEmailBody(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	if at := replyHeader.FindStringIndex(body); at != nil {
+		body = body[:at[0]]
+	}
+	if at := signatureStart.FindStringIndex(body); at != nil {
+		body = body[:at[0]]
+	}
+
+	lines := strings.Split(body, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), ">") {
+			kept = append(kept, strings.TrimRight(line, " \t"))
+		}
+	}
+	lines = kept
+
+	// a sign off followed by a few short lines, like a name and a phone number
+	for i := len(lines) - 1; i >= 0 && i >= len(lines)-6; i-- {
+		if signOff.MatchString(strings.TrimSpace(lines[i])) {
+			lines = lines[:i]
+			break
+		}
+	}
+
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.Join(lines, "\n"), "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) != "" && !disclaimer.MatchString(paragraph) {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// SummarizeEmail summarizes an RFC 5322 message to num sentences, see ParseEmail
+func (bag *Bag) SummarizeEmail(r io.Reader, num int) (*EmailSummary, error) {
+	message, err := ParseEmail(r)
+	if err != nil {
+		return nil, err
+	}
+	return bag.SummarizeThread([]*EmailMessage{message}, num)
+}
+
+// SummarizeThread summarizes the messages of a thread together to num sentences,
+// every selected sentence refers to the message it came from. Paragraphs end sentences.
+func (bag *Bag) SummarizeThread(messages []*EmailMessage, num int) (*EmailSummary, error) {
+	summary := &EmailSummary{}
+	texts := make([]*PreprocessedText, 0, len(messages))
+	var all []string
+	for _, message := range messages {
+		if summary.Subject == "" {
+			summary.Subject = strings.TrimSpace(subjectPrefix.ReplaceAllString(message.Subject, ""))
+		}
+		pre := Preprocess(message.Body, bag.preprocessors...)
+		texts = append(texts, pre)
+		all = append(all, pre.Text)
+	}
+	bag.resolveLanguage(strings.Join(all, "\n\n"))
+	summary.Language = bag.languageName()
+
+	var sentences []string
+	var owners []int
+	var spans [][2]int
+	for i, pre := range texts {
+		from := 0
+		for _, paragraph := range strings.Split(pre.Text, "\n\n") {
+			for _, sentence := range bag.splitBlock(paragraph) {
+				span := [2]int{-1, -1}
+				if at := strings.Index(pre.Text[from:], sentence); at >= 0 {
+					start := from + at
+					from = start + len(sentence)
+					span = [2]int{pre.OriginalOffset(start), pre.OriginalOffset(from)}
+				}
+				sentences = append(sentences, sentence)
+				owners = append(owners, i)
+				spans = append(spans, span)
+			}
+		}
+	}
+
+	idx, err := bag.summarizeSentences(nil, sentences, nil, num)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sentence := range bag.summarySentences(idx) {
+		sentence.Start, sentence.End = spans[sentence.Index][0], spans[sentence.Index][1]
+		summary.Sentences = append(summary.Sentences, &EmailSentence{
+			Sentence: sentence,
+			Message:  messages[owners[sentence.Index]],
+		})
+	}
+	return summary, nil
+}
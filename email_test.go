@@ -0,0 +1,130 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+)
+
+const email = "From: =?UTF-8?Q?Ren=C3=A9e_Martin?= <renee@example.com>\r\n" +
+	"To: team@example.com\r\n" +
+	"Subject: Re: Launch plan for the new website\r\n" +
+	"Date: Mon, 3 Jun 2024 10:15:00 +0200\r\n" +
+	"Message-ID: <abc123@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"mixed\"\r\n" +
+	"\r\n" +
+	"--mixed\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"alt\"\r\n" +
+	"\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Hi all,\r\n" +
+	"\r\n" +
+	"The launch of the new website moves to Thursday because the payment =\r\n" +
+	"provider needs one more day. The design team finished the new website pages=\r\n" +
+	" yesterday.\r\n" +
+	"\r\n" +
+	"Please test the checkout of the new website before Thursday.\r\n" +
+	"\r\n" +
+	"Thanks,\r\n" +
+	"Ren=C3=A9e\r\n" +
+	"\r\n" +
+	"This email and any attachments are confidential. If you have received this email in error, delete it.\r\n" +
+	"\r\n" +
+	"On Fri, May 31, 2024 at 9:00 AM Bob <bob@example.com> wrote:\r\n" +
+	"> When is the launch of the new website?\r\n" +
+	"> We need to know soon.\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>HTML version of the launch plan.</p>\r\n" +
+	"--alt--\r\n" +
+	"--mixed\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"Content-Disposition: attachment; filename=\"notes.txt\"\r\n" +
+	"\r\n" +
+	"Attached notes about something else entirely.\r\n" +
+	"--mixed--\r\n"
+
+var _ = Describe("Email", func() {
+	Describe("ParseEmail()", func() {
+		It("Should read the headers and the text the author wrote", func() {
+			message, err := ParseEmail(strings.NewReader(email))
+			Expect(err).To(BeNil())
+			Expect(message.From).To(Equal("Renée Martin <renee@example.com>"))
+			Expect(message.Subject).To(Equal("Re: Launch plan for the new website"))
+			Expect(message.MessageID).To(Equal("abc123@example.com"))
+			Expect(message.Date.Year()).To(Equal(2024))
+			Expect(message.Body).To(Equal("Hi all,\n\n" +
+				"The launch of the new website moves to Thursday because the payment provider needs one more day. The design team finished the new website pages yesterday.\n\n" +
+				"Please test the checkout of the new website before Thursday."))
+		})
+
+		It("Should fall back to the HTML part without quoted replies", func() {
+			raw := "From: bob@example.com\r\nSubject: Hello\r\nContent-Type: text/html; charset=iso-8859-1\r\n\r\n" +
+				"<html><body><p>The caf\xe9 opens at noon.</p><div>See you there.<br>Bob</div>" +
+				"<div class=\"gmail_quote\">On Monday Alice wrote: <blockquote>Old text.</blockquote></div></body></html>"
+			message, err := ParseEmail(strings.NewReader(raw))
+			Expect(err).To(BeNil())
+			Expect(message.From).To(Equal("bob@example.com"))
+			Expect(message.Body).To(Equal("The café opens at noon.\n\nSee you there.\nBob"))
+		})
+	})
+
+	Describe("CleanEmailBody()", func() {
+		It("Should remove forwarded messages, quotes and signatures", func() {
+			body := "See below.\n> quoted line\nMy answer is yes.\n\n-- \nJane Doe\nACME Inc.\n\n---------- Forwarded message ---------\nFrom: x"
+			Expect(CleanEmailBody(body)).To(Equal("See below.\nMy answer is yes."))
+
+			outlook := "Sounds good to me.\n\nFrom: Bob Smith\nSent: Monday, June 3, 2024 9:00 AM\nTo: Jane\nSubject: Plan\n\nOld message."
+			Expect(CleanEmailBody(outlook)).To(Equal("Sounds good to me."))
+		})
+	})
+
+	Describe("ParseMbox()", func() {
+		It("Should read every message", func() {
+			mbox := "From bob@example.com Mon Jun  3 10:00:00 2024\nFrom: bob@example.com\nSubject: First\n\nFirst body.\n>From the start.\n\n" +
+				"From alice@example.com Mon Jun  3 11:00:00 2024\nFrom: alice@example.com\nSubject: Re: First\n\nSecond body.\n"
+			messages, err := ParseMbox(strings.NewReader(mbox))
+			Expect(err).To(BeNil())
+			Expect(messages).To(HaveLen(2))
+			Expect(messages[0].Body).To(Equal("First body.\nFrom the start."))
+			Expect(messages[1].From).To(Equal("alice@example.com"))
+		})
+	})
+
+	Describe("SummarizeThread()", func() {
+		It("Should attribute every sentence to its message", func() {
+			first, err := ParseEmail(strings.NewReader(email))
+			Expect(err).To(BeNil())
+			second := &EmailMessage{
+				From:    "bob@example.com",
+				Subject: "Re: Re: Launch plan for the new website",
+				Body:    "Thursday works for the checkout tests of the new website.\n\nI will send the results of the tests on Wednesday.",
+			}
+
+			summary, err := New().SummarizeThread([]*EmailMessage{first, second}, 3)
+			Expect(err).To(BeNil())
+			Expect(summary.Subject).To(Equal("Launch plan for the new website"))
+			Expect(summary.Sentences).To(HaveLen(3))
+			for _, sentence := range summary.Sentences {
+				Expect(sentence.Message.Body[sentence.Start:sentence.End]).To(Equal(sentence.Text))
+				Expect(sentence.Text).NotTo(ContainSubstring("confidential"))
+				Expect(sentence.Text).NotTo(ContainSubstring("We need to know"))
+			}
+		})
+
+		It("Should summarize a single message", func() {
+			summary, err := New().SummarizeEmail(strings.NewReader(email), 2)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences).To(HaveLen(2))
+			Expect(summary.Sentences[0].Message.From).To(Equal("Renée Martin <renee@example.com>"))
+		})
+	})
+})
@@ -0,0 +1,224 @@
+// Package grpcserver implements the Summarizer gRPC service of the tldrpb package.
+// It shares its configuration and the handling of requests with the server package,
+// so calls coming when every slot is taken fail with ResourceExhausted, and timed out calls are not cancelled either.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/didasy/tldr"
+	"github.com/didasy/tldr/server"
+	"github.com/didasy/tldr/tldrpb"
+)
+
+// Server implements tldrpb.SummarizerServer
+type Server struct {
+	tldrpb.UnimplementedSummarizerServer
+
+	summarizer *server.Server
+}
+
+// New creates a server from config, see server.Config. MaxBodyBytes limits the size of a message.
+func New(config server.Config) *Server {
+	return &Server{
+		summarizer: server.New(config),
+	}
+}
+
+// NewFromServer creates a server handling calls like summarizer handles HTTP requests,
+// sharing its configuration and its slots, so the two of them never summarize more texts at once than it would alone
+func NewFromServer(summarizer *server.Server) *Server {
+	return &Server{
+		summarizer: summarizer,
+	}
+}
+
+// ListenAndServe serves on the address of the configuration until ctx is done,
+// then reports not serving to the health service and waits for the calls in progress before returning
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.summarizer.Config().Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve works like ListenAndServe on a listener
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	config := s.summarizer.Config()
+	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(int(config.MaxBodyBytes)))
+	tldrpb.RegisterSummarizerServer(grpcServer, s)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	healthServer.Shutdown()
+	time.Sleep(config.DrainDelay)
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(config.ShutdownTimeout):
+		grpcServer.Stop()
+	}
+	if err := <-served; !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Summarize summarizes a text
+func (s *Server) Summarize(ctx context.Context, req *tldrpb.SummarizeRequest) (*tldrpb.SummarizeResponse, error) {
+	summary, err := s.summarizer.Summarize(ctx, request(req))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return response(req.GetId(), summary), nil
+}
+
+// SummarizeBatch summarizes every text of the stream, in order.
+// A text failing gets an error in its response, the stream only ends when the client or ctx ends it.
+func (s *Server) SummarizeBatch(stream tldrpb.Summarizer_SummarizeBatchServer) error {
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		summary, err := s.summarizer.Summarize(ctx, request(req))
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		res := &tldrpb.SummarizeResponse{Id: req.GetId()}
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res = response(req.GetId(), summary)
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+// ExtractKeywords extracts the keywords of a text
+func (s *Server) ExtractKeywords(ctx context.Context, req *tldrpb.ExtractKeywordsRequest) (*tldrpb.ExtractKeywordsResponse, error) {
+	converted := &server.Request{
+		Text:     req.GetText(),
+		Keywords: int(req.GetKeywords()),
+	}
+	setSettings(converted, req.GetSettings())
+	keywords, err := s.summarizer.ExtractKeywords(ctx, converted)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	res := &tldrpb.ExtractKeywordsResponse{
+		Id:       req.GetId(),
+		Language: keywords.Language,
+	}
+	for _, keyword := range keywords.Keywords {
+		res.Keywords = append(res.Keywords, &tldrpb.Keyword{Text: keyword.Text, Score: keyword.Score})
+	}
+	return res, nil
+}
+
+// request converts a protobuf request to the request of the server package
+func request(req *tldrpb.SummarizeRequest) *server.Request {
+	converted := &server.Request{
+		Text:      req.GetText(),
+		Sentences: int(req.GetSentences()),
+		Ratio:     req.GetRatio(),
+	}
+	setSettings(converted, req.GetSettings())
+	return converted
+}
+
+// setSettings sets the settings of a protobuf request on the request of the server package
+func setSettings(converted *server.Request, settings *tldrpb.Settings) {
+	if settings == nil {
+		return
+	}
+	converted.Preset = settings.GetPreset()
+	converted.Algorithm = settings.GetAlgorithm()
+	converted.Weighing = settings.GetWeighing()
+	converted.Truncation = settings.GetTruncation()
+	converted.KeywordAlgorithm = settings.GetKeywordAlgorithm()
+	converted.Ellipsis = settings.Ellipsis
+	converted.Language = settings.Language
+	if settings.MaxCharacters != nil {
+		maxCharacters := int(settings.GetMaxCharacters())
+		converted.MaxCharacters = &maxCharacters
+	}
+	if settings.MaxWords != nil {
+		maxWords := int(settings.GetMaxWords())
+		converted.MaxWords = &maxWords
+	}
+	if settings.MaxTokens != nil {
+		maxTokens := int(settings.GetMaxTokens())
+		converted.MaxTokens = &maxTokens
+	}
+	converted.Damping = settings.Damping
+	converted.Tolerance = settings.Tolerance
+	converted.Threshold = settings.Threshold
+}
+
+// response converts a summary to a protobuf response
+func response(id string, summary *tldr.Summary) *tldrpb.SummarizeResponse {
+	res := &tldrpb.SummarizeResponse{
+		Id:       id,
+		Language: summary.Language,
+	}
+	for _, sentence := range summary.Sentences {
+		res.Sentences = append(res.Sentences, &tldrpb.Sentence{
+			Index: int32(sentence.Index),
+			Text:  sentence.Text,
+			Start: int32(sentence.Start),
+			End:   int32(sentence.End),
+			Score: sentence.Score,
+		})
+	}
+	return res
+}
+
+// statusError converts an error of the server package to a gRPC status
+func statusError(err error) error {
+	var invalid *server.RequestError
+	switch {
+	case errors.As(err, &invalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, server.ErrBusy):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,170 @@
+package grpcserver_test
+
+import (
+	"github.com/didasy/tldr"
+	. "github.com/didasy/tldr/grpcserver"
+	"github.com/didasy/tldr/server"
+	"github.com/didasy/tldr/tldrpb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var _ = Describe("Server", func() {
+	var sample string
+	var client tldrpb.SummarizerClient
+	var conn *grpc.ClientConn
+	var cancel context.CancelFunc
+	var served chan error
+
+	BeforeEach(func() {
+		data, err := os.ReadFile("../sample.txt")
+		Expect(err).To(BeNil())
+		sample = string(data)
+
+		listener := bufconn.Listen(1 << 20)
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		served = make(chan error, 1)
+		go func() {
+			served <- New(server.Config{Sentences: 2}).Serve(ctx, listener)
+		}()
+
+		conn, err = grpc.NewClient("passthrough:///bufconn",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).To(BeNil())
+		client = tldrpb.NewSummarizerClient(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		cancel()
+		Eventually(served).Should(Receive(BeNil()))
+	})
+
+	It("Should summarize a text", func() {
+		expected, err := tldr.New().SummarizeDetailed(sample, 3)
+		Expect(err).To(BeNil())
+
+		res, err := client.Summarize(context.Background(), &tldrpb.SummarizeRequest{Id: "a", Text: sample, Sentences: 3})
+		Expect(err).To(BeNil())
+		Expect(res.GetId()).To(Equal("a"))
+		Expect(res.GetSentences()).To(HaveLen(3))
+		for i, sentence := range res.GetSentences() {
+			Expect(int(sentence.GetIndex())).To(Equal(expected.Sentences[i].Index))
+			Expect(sentence.GetText()).To(Equal(expected.Sentences[i].Text))
+			Expect(int(sentence.GetStart())).To(Equal(expected.Sentences[i].Start))
+			Expect(sentence.GetScore()).To(BeNumerically("~", expected.Sentences[i].Score, 1e-6))
+		}
+	})
+
+	It("Should use the defaults of the server and the settings of the request", func() {
+		res, err := client.Summarize(context.Background(), &tldrpb.SummarizeRequest{Text: sample})
+		Expect(err).To(BeNil())
+		Expect(res.GetSentences()).To(HaveLen(2))
+
+		algorithm := "centrality"
+		centrality := tldr.New()
+		centrality.Algorithm = algorithm
+		expected, err := centrality.Summarize(sample, 1)
+		Expect(err).To(BeNil())
+		res, err = client.Summarize(context.Background(), &tldrpb.SummarizeRequest{
+			Text:      sample,
+			Sentences: 1,
+			Settings:  &tldrpb.Settings{Algorithm: &algorithm},
+		})
+		Expect(err).To(BeNil())
+		Expect(res.GetSentences()[0].GetText()).To(Equal(expected[0]))
+	})
+
+	It("Should reject invalid settings", func() {
+		weighing := "cosine"
+		_, err := client.Summarize(context.Background(), &tldrpb.SummarizeRequest{
+			Text:     sample,
+			Settings: &tldrpb.Settings{Weighing: &weighing},
+		})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("Should stream the summaries of a batch in order", func() {
+		stream, err := client.SummarizeBatch(context.Background())
+		Expect(err).To(BeNil())
+		Expect(stream.Send(&tldrpb.SummarizeRequest{Id: "1", Text: sample})).To(Succeed())
+		Expect(stream.Send(&tldrpb.SummarizeRequest{Id: "2", Text: sample, Sentences: -1})).To(Succeed())
+		Expect(stream.Send(&tldrpb.SummarizeRequest{Id: "3", Text: "Birds sing songs. Fish swim fast."})).To(Succeed())
+		Expect(stream.CloseSend()).To(Succeed())
+
+		var responses []*tldrpb.SummarizeResponse
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).To(BeNil())
+			responses = append(responses, res)
+		}
+		Expect(responses).To(HaveLen(3))
+		Expect(responses[0].GetId()).To(Equal("1"))
+		Expect(responses[0].GetSentences()).To(HaveLen(2))
+		Expect(responses[1].GetId()).To(Equal("2"))
+		Expect(responses[1].GetError()).To(ContainSubstring("sentences"))
+		Expect(responses[2].GetId()).To(Equal("3"))
+		Expect(responses[2].GetSentences()).To(HaveLen(2))
+	})
+
+	It("Should extract the keywords of a text", func() {
+		res, err := client.ExtractKeywords(context.Background(), &tldrpb.ExtractKeywordsRequest{Id: "k", Text: sample, Keywords: 5})
+		Expect(err).To(BeNil())
+		Expect(res.GetId()).To(Equal("k"))
+		Expect(res.GetKeywords()).To(HaveLen(5))
+		Expect(res.GetKeywords()[0].GetScore()).To(Equal(1.0))
+
+		_, err = client.ExtractKeywords(context.Background(), &tldrpb.ExtractKeywordsRequest{Text: sample, Keywords: -1})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("Should share the slots of the HTTP server", func() {
+		release := make(chan struct{})
+		defer close(release)
+		summarizer := server.New(server.Config{
+			Timeout:       10 * time.Millisecond,
+			MaxConcurrent: 1,
+			NewBag: func() *tldr.Bag {
+				bag := tldr.New()
+				bag.Algorithm = "custom"
+				bag.SetCustomAlgorithm(func(e []*tldr.Edge) []int {
+					<-release
+					return []int{0}
+				})
+				return bag
+			},
+		})
+		_, err := summarizer.Summarize(context.Background(), &server.Request{Text: sample})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		_, err = NewFromServer(summarizer).Summarize(context.Background(), &tldrpb.SummarizeRequest{Text: sample})
+		Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	})
+
+	It("Should serve the health service", func() {
+		res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+		Expect(err).To(BeNil())
+		Expect(res.GetStatus()).To(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+})
@@ -0,0 +1,108 @@
+package tldr
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The rules compressing the highest ranked sentence into a headline, for english text
+var (
+	// discourse markers starting a sentence, only "and" and "but" may go without a comma
+	headlineMarker = regexp.MustCompile(`^(?i:(?:and|but)\s+|(?:however|meanwhile|moreover|furthermore|additionally|in addition|also|still|yet|so|now|well|then|first|second|finally|overall|indeed|in fact|of course|nevertheless|nonetheless|therefore|thus|hence|as a result|for example|for instance|in other words|on the other hand|at the same time|in the meantime|after all)\s*,\s*)`)
+	// asides in brackets or between dashes
+	headlineParenthetical = regexp.MustCompile(`\s*\([^()]*\)|\s*\[[^\[\]]*\]|\s+(?:[–—]|--)\s+[^–—]*?\s+(?:[–—]|--)(?:\s+|$)`)
+	// who said it, leading the sentence, in the middle of it or ending it
+	headlineAttribution = []*regexp.Regexp{
+		regexp.MustCompile(`^(?i:according to\s+[^,]+,\s*)`),
+		// only "said" and "says" may introduce the reported clause without "that", the other verbs have objects
+		regexp.MustCompile(`^(?:[^\s,]+\s+){1,4}?(?:(?i:said|says)(?:\s+(?:on\s+)?[A-Z][a-z]+day)?\s+(?i:that\s+)?|(?i:announced|reported|stated|explained|noted|added|confirmed|warned)(?:\s+(?:on\s+)?[A-Z][a-z]+day)?\s+(?i:that)\s+)`),
+		regexp.MustCompile(`,["”’]?\s+(?:[^\s,]+\s+){0,3}?(?i:said|says|told|added|noted|stated|explained|reported|announced|wrote|according to)\b[^,]*?,\s+["“‘]?`),
+		regexp.MustCompile(`,["”’]?\s+(?:[^\s,]+\s+){0,3}?(?i:said|says|told|added|noted|stated|explained|reported|announced|wrote|according to)\b[^,]*$`),
+	}
+	// subordinate clauses and prepositional phrases leading the sentence, the elaboration after a colon,
+	// non-restrictive relative clauses, and the clauses ending the sentence
+	headlineClause = []*regexp.Regexp{
+		regexp.MustCompile(`^(?i:when|while|although|though|even though|because|since|after|before|if|as|once|unless|despite|whereas|whether|in|on|at|for|with|by|during|amid|following|under|throughout)\b[^,]*,\s*`),
+		regexp.MustCompile(`:\s+.*$`),
+		regexp.MustCompile(`,\s+(?i:which|who|whom|whose|where)\b[^,]*,\s+`),
+		regexp.MustCompile(`,\s+(?i:which|who|whom|whose|where|while|although|though|whereas|because|as|since|after|before|when|but|and|so|yet|including|with)\b.*$`),
+		regexp.MustCompile(`,\s+[a-z]+ing\b.*$`),
+	}
+)
+
+// Headline makes a one line title of text out of its highest ranked sentence, compressed to maxChars characters
+// with rules for english. Discourse markers starting it, asides in brackets or between dashes, and attributions
+// like "said the spokesperson" are always removed, and so is the punctuation ending it. Subordinate clauses are then
+// removed until the headline fits, and it is at last cut at a word boundary with the Ellipsis if it still does not.
+// It is not compressed further if maxChars is less than 1. An empty text has an empty headline, and a text whose
+// sentences could not be ranked, like one of a single sentence, is headed by its first sentence.
+func (bag *Bag) Headline(text string, maxChars int) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+	if _, err := bag.summarize(text, 1); err != nil {
+		return "", err
+	}
+	sentence := ""
+	for _, i := range bag.Ranks {
+		if i >= 0 && i < len(bag.OriginalSentences) {
+			sentence = bag.OriginalSentences[i]
+			break
+		}
+	}
+	if sentence == "" && len(bag.OriginalSentences) > 0 {
+		sentence = bag.OriginalSentences[0]
+	}
+	if sentence == "" {
+		return "", nil
+	}
+
+	headline := cleanHeadline(sentence)
+	for _, rule := range append([]*regexp.Regexp{headlineParenthetical}, headlineAttribution...) {
+		headline = applyHeadlineRule(headline, rule, " ")
+	}
+	for marked := ""; marked != headline; {
+		marked, headline = headline, applyHeadlineRule(headline, headlineMarker, "")
+	}
+	if maxChars < 1 {
+		return headline, nil
+	}
+
+	for _, rule := range headlineClause {
+		if utf8.RuneCountInString(headline) <= maxChars {
+			return headline, nil
+		}
+		headline = applyHeadlineRule(headline, rule, " ")
+	}
+	if utf8.RuneCountInString(headline) <= maxChars {
+		return headline, nil
+	}
+	return cutWord(headline, maxChars-utf8.RuneCountInString(bag.Ellipsis)) + bag.Ellipsis, nil
+}
+
+// applyHeadlineRule replaces the matches of rule in headline, keeping headline if nothing would be left of it
+func applyHeadlineRule(headline string, rule *regexp.Regexp, replacement string) string {
+	if compressed := cleanHeadline(rule.ReplaceAllString(headline, replacement)); compressed != "" {
+		return compressed
+	}
+	return headline
+}
+
+// cleanHeadline puts headline on one line, without the spaces before punctuation, the punctuation starting it
+// or ending it, except for question and exclamation marks, and with its first letter in upper case
+func cleanHeadline(headline string) string {
+	headline = strings.NewReplacer(" ,", ",", " .", ".", " ;", ";", " :", ":").Replace(OneLine(headline))
+	headline = strings.TrimLeftFunc(headline, func(r rune) bool {
+		return unicode.IsSpace(r) || (unicode.IsPunct(r) && !strings.ContainsRune(`"“‘'([`, r))
+	})
+	headline = strings.TrimRightFunc(headline, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(".,;:…", r)
+	})
+	if headline == "" {
+		return ""
+	}
+	first, size := utf8.DecodeRuneInString(headline)
+	return string(unicode.ToUpper(first)) + headline[size:]
+}
@@ -0,0 +1,99 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Headline", func() {
+	var bag *Bag
+
+	BeforeEach(func() {
+		bag = New()
+	})
+
+	headline := func(sentence string, maxChars int) string {
+		bag.Algorithm = "custom"
+		bag.SetCustomAlgorithm(func(e []*Edge) []int {
+			return []int{0}
+		})
+		title, err := bag.Headline(sentence, maxChars)
+		Expect(err).To(BeNil())
+		return title
+	}
+
+	It("Should make a headline of the highest ranked sentence", func() {
+		article := "The rocket launched on Monday. It carried a satellite. The rocket launched a satellite into orbit on Monday."
+		bag.Algorithm = "custom"
+		bag.SetCustomAlgorithm(func(e []*Edge) []int {
+			return []int{2, 0, 1}
+		})
+		title, err := bag.Headline(article, 0)
+		Expect(err).To(BeNil())
+		Expect(title).To(Equal("The rocket launched a satellite into orbit on Monday"))
+
+		title, err = New().Headline(text, 80)
+		Expect(err).To(BeNil())
+		Expect(title).NotTo(BeEmpty())
+		Expect(len([]rune(title))).To(BeNumerically("<=", 80))
+	})
+
+	It("Should remove discourse markers, asides and attributions", func() {
+		Expect(headline("However, the council (which met on Friday) approved the budget.", 0)).To(Equal("The council approved the budget"))
+		Expect(headline("And meanwhile, prices rose again.", 0)).To(Equal("Prices rose again"))
+		Expect(headline("The bridge will reopen in May, said the spokesperson.", 0)).To(Equal("The bridge will reopen in May"))
+		Expect(headline("The bridge will reopen in May, the mayor told reporters on Tuesday.", 0)).To(Equal("The bridge will reopen in May"))
+		Expect(headline("According to the police, the road is closed.", 0)).To(Equal("The road is closed"))
+		Expect(headline("Officials said Tuesday that the road is closed.", 0)).To(Equal("The road is closed"))
+		Expect(headline("The vaccine, researchers said, works against the new variant.", 0)).To(Equal("The vaccine works against the new variant"))
+		Expect(headline("The launch — delayed twice — finally happened.", 0)).To(Equal("The launch finally happened"))
+	})
+
+	It("Should keep the subject and verb of sentences that report no speech", func() {
+		Expect(headline("Apple announced a new iPhone with a faster chip on Monday.", 0)).To(Equal("Apple announced a new iPhone with a faster chip on Monday"))
+		Expect(headline("The company reported a record loss in the third quarter.", 0)).To(Equal("The company reported a record loss in the third quarter"))
+		Expect(headline("The minister warned of higher taxes next year.", 0)).To(Equal("The minister warned of higher taxes next year"))
+		Expect(headline("The company announced on Monday that it will close the plant.", 0)).To(Equal("It will close the plant"))
+		Expect(headline("The mayor says the road is closed.", 0)).To(Equal("The road is closed"))
+	})
+
+	It("Should head a text that could not be ranked with its first sentence", func() {
+		title, err := New().Headline("Only one sentence here about the city council budget.", 0)
+		Expect(err).To(BeNil())
+		Expect(title).To(Equal("Only one sentence here about the city council budget"))
+	})
+
+	It("Should remove clauses only until the headline fits", func() {
+		sentence := "After months of talks, the union accepted the offer, which raises wages by five percent."
+		Expect(headline(sentence, 0)).To(Equal("After months of talks, the union accepted the offer, which raises wages by five percent"))
+		Expect(headline(sentence, 70)).To(Equal("The union accepted the offer, which raises wages by five percent"))
+		Expect(headline(sentence, 40)).To(Equal("The union accepted the offer"))
+		Expect(headline("The team won the final, beating the champions in extra time.", 30)).To(Equal("The team won the final"))
+		Expect(headline("In a statement on Friday, the club named its new coach: a former player.", 40)).To(Equal("The club named its new coach"))
+	})
+
+	It("Should cut the headline at a word boundary when the rules are not enough", func() {
+		bag.Ellipsis = "…"
+		Expect(headline("The committee approved the long awaited budget.", 25)).To(Equal("The committee approved…"))
+		Expect(headline("Approved.", 0)).To(Equal("Approved"))
+	})
+
+	It("Should have an empty headline for an empty text", func() {
+		title, err := bag.Headline("", 50)
+		Expect(err).To(BeNil())
+		Expect(title).To(BeEmpty())
+
+		// not even after summarizing another text
+		_, err = bag.Summarize(text, 3)
+		Expect(err).To(BeNil())
+		title, err = bag.Headline(" \n", 50)
+		Expect(err).To(BeNil())
+		Expect(title).To(BeEmpty())
+		bag.OriginalSentences = []string{"The council approved the budget."}
+		title, err = bag.Headline("", 50)
+		Expect(err).To(BeNil())
+		Expect(title).To(BeEmpty())
+	})
+})
@@ -0,0 +1,456 @@
+package tldr
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLDocument is the main content extracted from an HTML document
+type HTMLDocument struct {
+	Title  string
+	Blocks []*HTMLBlock // blocks of the main content, in document order
+	Root   *html.Node   // the parsed document, with the boilerplate removed
+}
+
+// HTMLBlock is a block element of the main content of an HTML document
+type HTMLBlock struct {
+	Tag     string     // tag name of the element, example: "p"
+	Path    string     // CSS selector of the element, example: "html > body > article > p:nth-of-type(2)"
+	Text    string     // text of the block, with whitespace collapsed
+	Heading bool       // the block is one of h1 to h6
+	Node    *html.Node // the element in Root
+}
+
+// HTMLSummary is the result of summarizing an HTML document
+type HTMLSummary struct {
+	Title     string
+	Language  string
+	Sentences []*HTMLSentence // selected sentences, in document order
+}
+
+// HTMLSentence is a sentence selected from an HTML document, along with the block it came from
+type HTMLSentence struct {
+	*Sentence
+	Block *HTMLBlock
+}
+
+// Class and id patterns of the readability algorithm, used to tell content from boilerplate
+var (
+	unlikelyCandidates = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|disqus|extra|foot|header|legends|menu|modal|nav|newsletter|pager|popup|promo|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|tags|tool|widget|(^|[-_ ])ads?([-_ ]|$)`)
+	maybeCandidates    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveCandidates = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story`)
+	negativeCandidates = regexp.MustCompile(`(?i)hidden|banner|combx|comment|com-|contact|foot|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+	hiddenStyle        = regexp.MustCompile(`(?i)display\s*:\s*none|visibility\s*:\s*hidden`)
+)
+
+// elements never part of the main content
+var boilerplateElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Iframe: true, atom.Svg: true,
+	atom.Canvas: true, atom.Form: true, atom.Button: true, atom.Input: true, atom.Select: true,
+	atom.Textarea: true, atom.Template: true, atom.Object: true, atom.Embed: true, atom.Nav: true,
+	atom.Aside: true, atom.Footer: true, atom.Link: true, atom.Meta: true, atom.Head: true,
+}
+
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Dd: true,
+	atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Figcaption: true, atom.Figure: true,
+	atom.Footer: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true,
+	atom.H6: true, atom.Header: true, atom.Hr: true, atom.Li: true, atom.Main: true, atom.Nav: true,
+	atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true, atom.Table: true,
+	atom.Tbody: true, atom.Td: true, atom.Tfoot: true, atom.Th: true, atom.Thead: true,
+	atom.Tr: true, atom.Ul: true,
+}
+
+var headingElements = map[atom.Atom]bool{
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+// ExtractHTML parses an HTML document and extracts its main content,
+// leaving out navigation, footers, scripts and other boilerplate
+func ExtractHTML(r io.Reader) (*HTMLDocument, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("tldr: parsing html: %w", err)
+	}
+
+	doc := &HTMLDocument{
+		Root: root,
+	}
+	if title := findElement(root, atom.Title); title != nil {
+		doc.Title = collapseSpaces(textContent(title))
+	}
+
+	removeBoilerplate(root)
+	for _, content := range mainContent(root) {
+		collectBlocks(content, &doc.Blocks)
+	}
+
+	if doc.Title == "" {
+		for _, block := range doc.Blocks {
+			if block.Heading {
+				doc.Title = block.Text
+				break
+			}
+		}
+	}
+	return doc, nil
+}
+
+// SummarizeHTML summarizes the main content of an HTML document to num sentences.
+// Block elements end sentences, headings and preformatted blocks are never selected.
+func (bag *Bag) SummarizeHTML(r io.Reader, num int) (*HTMLSummary, error) {
+	doc, err := ExtractHTML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*HTMLBlock, 0, len(doc.Blocks))
+	texts := make([]string, 0, len(doc.Blocks))
+	for _, block := range doc.Blocks {
+		if block.Heading || block.Tag == "pre" {
+			continue
+		}
+		blocks = append(blocks, block)
+		texts = append(texts, Preprocess(block.Text, bag.preprocessors...).Text)
+	}
+
+	bag.resolveLanguage(strings.Join(texts, "\n"))
+	var sentences []string
+	var owners []*HTMLBlock
+	for i, text := range texts {
+		for _, sentence := range bag.splitBlock(text) {
+			sentences = append(sentences, sentence)
+			owners = append(owners, blocks[i])
+		}
+	}
+
+	idx, err := bag.summarizeSentences(nil, sentences, nil, num)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &HTMLSummary{
+		Title:    doc.Title,
+		Language: bag.languageName(),
+	}
+	for _, sentence := range bag.summarySentences(idx) {
+		summary.Sentences = append(summary.Sentences, &HTMLSentence{
+			Sentence: sentence,
+			Block:    owners[sentence.Index],
+		})
+	}
+	return summary, nil
+}
+
+// removeBoilerplate removes the elements that are unlikely to be part of the main content
+func removeBoilerplate(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		switch {
+		case c.Type == html.CommentNode:
+			n.RemoveChild(c)
+		case c.Type == html.ElementNode && isBoilerplate(c):
+			n.RemoveChild(c)
+		default:
+			removeBoilerplate(c)
+		}
+		c = next
+	}
+}
+
+func isBoilerplate(n *html.Node) bool {
+	if boilerplateElements[n.DataAtom] {
+		return true
+	}
+	if hasAttr(n, "hidden") || attr(n, "aria-hidden") == "true" || hiddenStyle.MatchString(attr(n, "style")) {
+		return true
+	}
+	switch n.DataAtom {
+	case atom.Html, atom.Body, atom.Article, atom.Main, atom.A:
+		return false
+	case atom.Header:
+		return findAncestor(n, atom.Article) == nil
+	}
+	match := attr(n, "class") + " " + attr(n, "id")
+	return unlikelyCandidates.MatchString(match) && !maybeCandidates.MatchString(match)
+}
+
+// mainContent scores the elements holding paragraphs and returns the best one
+// along with its siblings that look like content too
+func mainContent(root *html.Node) []*html.Node {
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+
+	var score func(n *html.Node)
+	score = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode {
+				score(c)
+			}
+		}
+		if !isScorable(n) {
+			return
+		}
+		text := collapseSpaces(textContent(n))
+		if len([]rune(text)) < 25 {
+			return
+		}
+
+		contentScore := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len([]rune(text)))/100, 3)
+		level := 0
+		for ancestor := n.Parent; ancestor != nil && ancestor.Type == html.ElementNode && level < 3; ancestor = ancestor.Parent {
+			if _, exists := scores[ancestor]; !exists {
+				scores[ancestor] = initialScore(ancestor)
+				candidates = append(candidates, ancestor)
+			}
+			switch level {
+			case 0:
+				scores[ancestor] += contentScore
+			case 1:
+				scores[ancestor] += contentScore / 2
+			default:
+				scores[ancestor] += contentScore / float64(level*3)
+			}
+			level++
+		}
+	}
+	score(root)
+
+	var top *html.Node
+	for _, candidate := range candidates {
+		scores[candidate] *= 1 - linkDensity(candidate)
+		if top == nil || scores[candidate] > scores[top] {
+			top = candidate
+		}
+	}
+	if top == nil {
+		if body := findElement(root, atom.Body); body != nil {
+			return []*html.Node{body}
+		}
+		return []*html.Node{root}
+	}
+
+	// siblings scoring close to the top candidate, or long paragraphs, are content as well
+	if top.Parent == nil {
+		return []*html.Node{top}
+	}
+	var content []*html.Node
+	threshold := math.Max(10, scores[top]*0.2)
+	for sibling := top.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+		if sibling.Type != html.ElementNode {
+			continue
+		}
+		if sibling == top {
+			content = append(content, sibling)
+			continue
+		}
+		if s, exists := scores[sibling]; exists && s >= threshold {
+			content = append(content, sibling)
+			continue
+		}
+		if sibling.DataAtom == atom.P {
+			text := collapseSpaces(textContent(sibling))
+			density := linkDensity(sibling)
+			if (len(text) > 80 && density < 0.25) || (len(text) > 0 && density == 0 && strings.ContainsAny(text, ".!?")) {
+				content = append(content, sibling)
+			}
+		}
+	}
+	return content
+}
+
+// isScorable reports whether n holds paragraph text, divs count only if they hold no other block
+func isScorable(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.P, atom.Pre, atom.Td, atom.Blockquote:
+		return true
+	case atom.Div:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && blockElements[c.DataAtom] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func initialScore(n *html.Node) float64 {
+	var score float64
+	switch n.DataAtom {
+	case atom.Div, atom.Article, atom.Main, atom.Section:
+		score = 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score = 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		score = -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score = -5
+	}
+
+	for _, value := range []string{attr(n, "class"), attr(n, "id")} {
+		if value == "" {
+			continue
+		}
+		if negativeCandidates.MatchString(value) {
+			score -= 25
+		}
+		if positiveCandidates.MatchString(value) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// linkDensity is the share of the text of n inside links
+func linkDensity(n *html.Node) float64 {
+	length := len(collapseSpaces(textContent(n)))
+	if length == 0 {
+		return 0
+	}
+	links := 0
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.DataAtom == atom.A {
+				links += len(collapseSpaces(textContent(c)))
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+	return float64(links) / float64(length)
+}
+
+// collectBlocks appends the blocks of text under n, text between nested blocks belongs to n
+func collectBlocks(n *html.Node, blocks *[]*HTMLBlock) {
+	var inline strings.Builder
+	flush := func() {
+		if text := collapseSpaces(inline.String()); text != "" {
+			*blocks = append(*blocks, &HTMLBlock{
+				Tag:     n.Data,
+				Path:    elementPath(n),
+				Text:    text,
+				Heading: headingElements[n.DataAtom],
+				Node:    n,
+			})
+		}
+		inline.Reset()
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch {
+		case c.Type == html.TextNode:
+			inline.WriteString(c.Data)
+		case c.Type != html.ElementNode:
+		case c.DataAtom == atom.Br:
+			inline.WriteString(" ")
+		case blockElements[c.DataAtom] || containsBlock(c):
+			flush()
+			collectBlocks(c, blocks)
+		default:
+			inline.WriteString(textContent(c))
+		}
+	}
+	flush()
+}
+
+func containsBlock(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (blockElements[c.DataAtom] || containsBlock(c)) {
+			return true
+		}
+	}
+	return false
+}
+
+// elementPath builds a CSS selector locating n in its document
+func elementPath(n *html.Node) string {
+	var segments []string
+	for ; n != nil && n.Type == html.ElementNode; n = n.Parent {
+		segment := n.Data
+		if id := attr(n, "id"); id != "" {
+			segment += "#" + id
+		} else if n.Parent != nil {
+			nth, count := 0, 0
+			for sibling := n.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+				if sibling.Type == html.ElementNode && sibling.Data == n.Data {
+					count++
+					if sibling == n {
+						nth = count
+					}
+				}
+			}
+			if count > 1 {
+				segment += fmt.Sprintf(":nth-of-type(%d)", nth)
+			}
+		}
+		segments = append([]string{segment}, segments...)
+	}
+	return strings.Join(segments, " > ")
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == atom.Br {
+			b.WriteString(" ")
+			continue
+		}
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
+
+func collapseSpaces(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func findAncestor(n *html.Node, a atom.Atom) *html.Node {
+	for n = n.Parent; n != nil; n = n.Parent {
+		if n.Type == html.ElementNode && n.DataAtom == a {
+			return n
+		}
+	}
+	return nil
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"os"
+	"strings"
+)
+
+var _ = Describe("HTML input", func() {
+	var page *os.File
+
+	BeforeEach(func() {
+		page, err = os.Open("./sample.html")
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		page.Close()
+	})
+
+	Describe("ExtractHTML()", func() {
+		It("Should extract the article and leave out the boilerplate", func() {
+			doc, err := ExtractHTML(page)
+			Expect(err).To(BeNil())
+			Expect(doc.Title).To(Equal("George Lucas Picks Architects for His Museum of Narrative Art"))
+			Expect(doc.Blocks).To(HaveLen(10))
+			Expect(doc.Blocks[0].Heading).To(BeTrue())
+			for _, block := range doc.Blocks {
+				Expect(block.Path).To(HavePrefix("html > body > div#wrapper > article > "))
+				Expect(block.Text).NotTo(ContainSubstring("script"))
+				Expect(block.Text).NotTo(ContainSubstring("Share this"))
+				Expect(block.Text).NotTo(ContainSubstring("Copyright"))
+			}
+		})
+
+		It("Should treat block elements as boundaries", func() {
+			doc, err := ExtractHTML(strings.NewReader("<body><div><p>Short intro</p>Loose text <b>inside</b> the div<ul><li>One item</li></ul></div></body>"))
+			Expect(err).To(BeNil())
+			texts := []string{}
+			for _, block := range doc.Blocks {
+				texts = append(texts, block.Tag+": "+block.Text)
+			}
+			Expect(texts).To(Equal([]string{"p: Short intro", "div: Loose text inside the div", "li: One item"}))
+		})
+	})
+
+	Describe("SummarizeHTML()", func() {
+		It("Should summarize the article and refer back to the source elements", func() {
+			summary, err := New().SummarizeHTML(page, 3)
+			Expect(err).To(BeNil())
+			Expect(summary.Title).To(HavePrefix("George Lucas"))
+			Expect(summary.Sentences).To(HaveLen(3))
+			for _, sentence := range summary.Sentences {
+				Expect(sentence.Block.Tag).To(Equal("p"))
+				Expect(sentence.Block.Text).To(ContainSubstring(sentence.Text))
+				Expect(text).To(ContainSubstring(sentence.Text))
+			}
+		})
+
+		It("Should keep paragraphs without terminal punctuation as sentences", func() {
+			summary, err := New().SummarizeHTML(strings.NewReader(`<article>
+				<p>Cats like to sleep in the sun</p>
+				<p>Dogs like to sleep in the shade</p>
+				<p>Birds like to sing in the morning</p>
+			</article>`), 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences).To(HaveLen(1))
+			Expect([]string{
+				"Cats like to sleep in the sun",
+				"Dogs like to sleep in the shade",
+				"Birds like to sing in the morning",
+			}).To(ContainElement(summary.Sentences[0].Text))
+		})
+	})
+})
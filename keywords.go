@@ -0,0 +1,298 @@
+package tldr
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alixaxel/pagerank"
+)
+
+// Keywords is the result of extracting the keywords of a text
+type Keywords struct {
+	Language string     `json:"language,omitempty"` // name of the language used to process the text, empty if none
+	Keywords []*Keyword `json:"keywords"`           // from the most to the least relevant
+}
+
+// Keyword is a word or phrase of a text
+type Keyword struct {
+	Text  string  `json:"text"`  // lower cased, as it appears the most in the text
+	Score float64 `json:"score"` // score of the keyword, the highest of the text is 1
+}
+
+const (
+	// words closer than this in a sentence, stop words left out, are linked in the graph of TextRank
+	keywordWindow = 2
+	// longest keyphrase, in words
+	keyphraseWords = 3
+)
+
+// keyphraseDelimiter matches the punctuation inside sentences that ends a keyphrase
+var keyphraseDelimiter = regexp.MustCompile(`[,;:()\[\]{}"“”«»]|\s[-–—]+\s`)
+
+// keywordCandidate is a word or phrase of the text, under its normalized form
+type keywordCandidate struct {
+	words []string       // normalized words
+	forms map[string]int // number of times every form of it appears in the text
+	score float64
+}
+
+// form returns the form of the candidate appearing the most, the first in alphabetical order on ties
+func (c *keywordCandidate) form() string {
+	best := ""
+	for form, count := range c.forms {
+		if count > c.forms[best] || (count == c.forms[best] && form < best) {
+			best = form
+		}
+	}
+	return best
+}
+
+// count returns the number of times the candidate appears in the text
+func (c *keywordCandidate) count() int {
+	count := 0
+	for _, n := range c.forms {
+		count += n
+	}
+	return count
+}
+
+// replaced tells if a longer phrase contains the candidate and appears as often
+func (c *keywordCandidate) replaced(phrases map[string]*keywordCandidate) bool {
+	key := " " + strings.Join(c.words, " ") + " "
+	for _, phrase := range phrases {
+		if len(phrase.words) > len(c.words) && phrase.count() >= c.count() &&
+			strings.Contains(" "+strings.Join(phrase.words, " ")+" ", key) {
+			return true
+		}
+	}
+	return false
+}
+
+// The algorithms of ExtractKeywords
+const (
+	KEYWORDS_TEXTRANK = "textrank"
+	KEYWORDS_YAKE     = "yake"
+)
+
+// keywordWords splits and normalizes the words of a text for its keywords
+type keywordWords struct {
+	isStopWord func(word string) bool
+	stemmer    func(word string) string
+	dict       map[string]int // the only words allowed, nil for any
+	detected   *Language      // language detected in the text when the bag has no language or stop words
+}
+
+// keywordWords returns how the words of text are normalized for its keywords, the language must be resolved.
+// Without a language or stop words, the stop words of the language detected in text are used.
+func (bag *Bag) keywordWords(text string) *keywordWords {
+	w := &keywordWords{
+		isStopWord: bag.isStopWord,
+		stemmer:    bag.currentStemmer(),
+	}
+	if bag.stopWords == nil && bag.language == nil {
+		if w.detected = DetectLanguage(text); w.detected != nil {
+			w.isStopWord = w.detected.IsStopWord
+		}
+	}
+	if len(bag.Dict) > 0 && !bag.dictCreated {
+		w.dict = bag.Dict
+	}
+	return w
+}
+
+// normalize returns the normalized form of word, or an empty string if it cannot be a keyword
+func (w *keywordWords) normalize(word string) string {
+	if !isKeyword(word) || w.isStopWord(word) {
+		return ""
+	}
+	if w.stemmer != nil {
+		word = w.stemmer(word)
+	}
+	if w.dict != nil && w.dict[word] == 0 {
+		return ""
+	}
+	return word
+}
+
+// ExtractKeywords extracts the num most relevant keywords and keyphrases of text, all of them if num is less than 1,
+// with the algorithm of KeywordAlgorithm. Words are split and normalized with the tokenizers, stop words and stemmer
+// of the bag, and restricted to the dictionary set with SetDictionary, if any. Without a language or stop words,
+// the stop words of the language detected in the text are left out.
+func (bag *Bag) ExtractKeywords(text string, num int) (*Keywords, error) {
+	text = strings.TrimSpace(Preprocess(text, bag.preprocessors...).Text)
+	bag.resolveLanguage(text)
+	result := &Keywords{
+		Language: bag.languageName(),
+		Keywords: []*Keyword{},
+	}
+
+	var keywords []*Keyword
+	switch bag.KeywordAlgorithm {
+	case KEYWORDS_TEXTRANK:
+		keywords = bag.textRankKeywords(text)
+	case KEYWORDS_YAKE:
+		keywords = bag.yakeKeywords(text, num)
+	default:
+		return nil, fmt.Errorf("tldr: unknown keyword algorithm %q", bag.KeywordAlgorithm)
+	}
+	if len(keywords) == 0 {
+		return result, nil
+	}
+
+	if num > 0 && num < len(keywords) {
+		keywords = keywords[:num]
+	}
+	highest := keywords[0].Score
+	for _, keyword := range keywords {
+		if highest > 0 {
+			keyword.Score /= highest
+		}
+	}
+	result.Keywords = keywords
+	return result, nil
+}
+
+// textRankKeywords ranks the words of text with TextRank, by running pagerank with Damping and Tolerance
+// over the graph of the words following each other in the sentences. Words of the top third following each other
+// more than once are then merged into keyphrases of up to 3 words, scored with the sum of the scores of their words
+// like RAKE. A keyword is left out when a longer keyphrase containing it appears as often.
+func (bag *Bag) textRankKeywords(text string) []*Keyword {
+	normalizer := bag.keywordWords(text)
+
+	// every run of candidate words, split by stop words and punctuation, and every sentence as a sequence of words
+	words := make(map[string]*keywordCandidate)
+	var runs, sequences [][]string
+	var runForms [][]string
+	for _, sentence := range bag.splitBlock(text) {
+		var sequence []string
+		for _, fragment := range keyphraseDelimiter.Split(sentence, -1) {
+			var run, forms []string
+			for _, word := range append(bag.wordTokenizer(fragment), "") {
+				normalized := normalizer.normalize(word)
+				if normalized == "" {
+					if len(run) > 0 {
+						runs, runForms = append(runs, run), append(runForms, forms)
+						run, forms = nil, nil
+					}
+					continue
+				}
+
+				candidate, exists := words[normalized]
+				if !exists {
+					candidate = &keywordCandidate{words: []string{normalized}, forms: map[string]int{}}
+					words[normalized] = candidate
+				}
+				candidate.forms[word]++
+				run, forms = append(run, normalized), append(forms, word)
+				sequence = append(sequence, normalized)
+			}
+		}
+		sequences = append(sequences, sequence)
+	}
+
+	ranked := bag.textRank(words, sequences)
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	// merge the top words following each other more than once into keyphrases
+	top := make(map[string]bool, len(ranked)/3+1)
+	for _, candidate := range ranked[:len(ranked)/3+1] {
+		top[candidate.words[0]] = true
+	}
+	phrases := make(map[string]*keywordCandidate)
+	for r, run := range runs {
+		for start := 0; start < len(run); start++ {
+			for end := start + 1; end < len(run) && end-start < keyphraseWords && top[run[start]] && top[run[end]]; end++ {
+				key := strings.Join(run[start:end+1], " ")
+				phrase, exists := phrases[key]
+				if !exists {
+					phrase = &keywordCandidate{words: run[start : end+1], forms: map[string]int{}}
+					for _, word := range phrase.words {
+						phrase.score += words[word].score
+					}
+					phrases[key] = phrase
+				}
+				phrase.forms[strings.Join(runForms[r][start:end+1], " ")]++
+			}
+		}
+	}
+	for key, phrase := range phrases {
+		if phrase.count() < 2 {
+			delete(phrases, key)
+		}
+	}
+
+	// a word or keyphrase is replaced by a longer keyphrase appearing as often
+	for _, phrase := range phrases {
+		ranked = append(ranked, phrase)
+	}
+	keywords := make([]*Keyword, 0, len(ranked))
+	for _, candidate := range ranked {
+		if !candidate.replaced(phrases) {
+			keywords = append(keywords, &Keyword{Text: candidate.form(), Score: candidate.score})
+		}
+	}
+	sort.SliceStable(keywords, func(i, j int) bool {
+		if keywords[i].Score != keywords[j].Score {
+			return keywords[i].Score > keywords[j].Score
+		}
+		return keywords[i].Text < keywords[j].Text
+	})
+	return keywords
+}
+
+// textRank scores the words with pagerank over the graph linking the words closer than keywordWindow in the sequences,
+// and returns the ones linked to any other, from the highest score
+func (bag *Bag) textRank(words map[string]*keywordCandidate, sequences [][]string) []*keywordCandidate {
+	ids := make(map[string]uint32, len(words))
+	var nodes []*keywordCandidate
+	graph := pagerank.NewGraph()
+	defer graph.Reset()
+	for _, sequence := range sequences {
+		for i, word := range sequence {
+			for j := i + 1; j < len(sequence) && j < i+keywordWindow; j++ {
+				if sequence[j] == word {
+					continue
+				}
+				for _, w := range []string{word, sequence[j]} {
+					if _, exists := ids[w]; !exists {
+						ids[w] = uint32(len(nodes))
+						nodes = append(nodes, words[w])
+					}
+				}
+				graph.Link(ids[word], ids[sequence[j]], 1)
+				graph.Link(ids[sequence[j]], ids[word], 1)
+			}
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	graph.Rank(bag.Damping, bag.Tolerance, func(id uint32, rank float64) {
+		// pagerank sums in the random order of maps, rounding keeps words of the same rank tied from run to run
+		nodes[id].score = math.Round(rank*1e12) / 1e12
+	})
+	ranked := append([]*keywordCandidate(nil), nodes...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].words[0] < ranked[j].words[0]
+	})
+	return ranked
+}
+
+// isKeyword tells if a word can be a keyword, it must have a letter and more than one character
+func isKeyword(word string) bool {
+	if utf8.RuneCountInString(word) < 2 {
+		return false
+	}
+	return strings.IndexFunc(word, unicode.IsLetter) >= 0
+}
@@ -0,0 +1,79 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Keywords", func() {
+	article := "Machine learning models need data. Machine learning engineers clean the data before training. " +
+		"Training machine learning models takes time, and the data decides how good the models are. " +
+		"Engineers also tune the model."
+
+	It("Should rank the keywords and merge them into keyphrases", func() {
+		keywords, err := New().ExtractKeywords(article, 0)
+		Expect(err).To(BeNil())
+		Expect(keywords.Language).To(BeEmpty())
+		Expect(keywords.Keywords).NotTo(BeEmpty())
+		Expect(keywords.Keywords[0].Score).To(Equal(1.0))
+
+		texts := []string{}
+		for i, keyword := range keywords.Keywords {
+			texts = append(texts, keyword.Text)
+			if i > 0 {
+				Expect(keyword.Score).To(BeNumerically("<=", keywords.Keywords[i-1].Score))
+			}
+		}
+		Expect(texts).To(ContainElement("machine learning"))
+		Expect(texts).To(ContainElement("data"))
+		Expect(texts).NotTo(ContainElement("machine"))
+		Expect(texts).NotTo(ContainElement("the"))
+		Expect(texts).NotTo(ContainElement("and"))
+	})
+
+	It("Should return num keywords at most", func() {
+		keywords, err := New().ExtractKeywords(article, 2)
+		Expect(err).To(BeNil())
+		Expect(keywords.Keywords).To(HaveLen(2))
+	})
+
+	It("Should group the words by their stem with the stemmer of the language", func() {
+		texts := func(keywords *Keywords) []string {
+			texts := []string{}
+			for _, keyword := range keywords.Keywords {
+				texts = append(texts, keyword.Text)
+			}
+			return texts
+		}
+		keywords, err := New().ExtractKeywords(article, 0)
+		Expect(err).To(BeNil())
+		Expect(texts(keywords)).To(ContainElement("models"))
+		Expect(texts(keywords)).To(ContainElement("model"))
+
+		bag := New()
+		bag.Language = "english"
+		keywords, err = bag.ExtractKeywords(article, 0)
+		Expect(err).To(BeNil())
+		Expect(keywords.Language).To(Equal("english"))
+		Expect(texts(keywords)).To(ContainElement("models"))
+		Expect(texts(keywords)).NotTo(ContainElement("model"))
+	})
+
+	It("Should only keep the words of the dictionary", func() {
+		bag := New()
+		bag.SetDictionary(map[string]int{"data": 1, "models": 2, "engineers": 3})
+		keywords, err := bag.ExtractKeywords(article, 0)
+		Expect(err).To(BeNil())
+		for _, keyword := range keywords.Keywords {
+			Expect(keyword.Text).To(MatchRegexp(`^(data|models|engineers)( (data|models|engineers))*$`))
+		}
+	})
+
+	It("Should return no keywords for an empty text", func() {
+		keywords, err := New().ExtractKeywords("   ", 5)
+		Expect(err).To(BeNil())
+		Expect(keywords.Keywords).To(BeEmpty())
+	})
+})
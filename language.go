@@ -0,0 +1,273 @@
+package tldr
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Language bundles the sentence rules, stop words and stemmer used to process a natural language.
+// Its fields should not be changed once it has been used.
+type Language struct {
+	Name          string                   // lowercased english name, example: "english"
+	Code          string                   // ISO 639-1 code, example: "en"
+	StopWords     []string                 // lowercased words left out of the sentence vectors
+	Abbreviations []string                 // lowercased words that don't end a sentence when followed by a period
+	Stemmer       func(word string) string // can be nil
+
+	sample        string // text used to train the profile, the stop words are always added to it
+	once          sync.Once
+	profile       map[string]int
+	stopWords     map[string]bool
+	abbreviations map[string]bool
+}
+
+// Languages holds the languages known to LookupLanguage and DetectLanguage, keyed by name
+var Languages = map[string]*Language{
+	english.Name:    english,
+	german.Name:     german,
+	spanish.Name:    spanish,
+	indonesian.Name: indonesian,
+}
+
+const (
+	LANGUAGE_AUTO = "auto" // detect the language from the text
+
+	languageProfileSize   = 300   // number of most frequent trigrams compared
+	languageDetectionSize = 10000 // bytes of text looked at to detect the language
+)
+
+func (l *Language) prepare() {
+	l.once.Do(func() {
+		l.stopWords = make(map[string]bool, len(l.StopWords))
+		for _, word := range l.StopWords {
+			l.stopWords[word] = true
+		}
+		l.abbreviations = make(map[string]bool, len(l.Abbreviations))
+		for _, word := range l.Abbreviations {
+			l.abbreviations[word] = true
+		}
+		l.profile = trigramProfile(l.sample + " " + strings.Join(l.StopWords, " "))
+	})
+}
+
+// IsStopWord reports whether the lowercased word is one of the language stop words
+func (l *Language) IsStopWord(word string) bool {
+	l.prepare()
+	return l.stopWords[word]
+}
+
+// TokenizeSentences works like TokenizeSentences, but doesn't end a sentence
+// at known abbreviations, initials, or when the next word starts in lowercase
+func (l *Language) TokenizeSentences(text string) []string {
+	l.prepare()
+	tokens := []string{}
+
+	text = strings.TrimSpace(text)
+
+	from := 0
+	for _, c := range sentenceTokenizer.FindAllStringIndex(text, -1) {
+		if text[c[0]] == '.' && !l.endsSentence(text[from:c[0]], text[c[1]:]) {
+			continue
+		}
+		str := text[from : c[0]+1]
+		str = strings.TrimSpace(str)
+		tokens = append(tokens, str)
+		from = c[1]
+	}
+
+	return tokens
+}
+
+// endsSentence decides whether a period between before and after ends a sentence
+func (l *Language) endsSentence(before, after string) bool {
+	word := before[strings.LastIndexFunc(before, unicode.IsSpace)+1:]
+	word = strings.TrimLeft(word, "([{\"'¿¡")
+	if l.abbreviations[strings.ToLower(word)] {
+		return false
+	}
+
+	// initials, like the J. in J. Smith
+	if first, size := utf8.DecodeRuneInString(word); size == len(word) && unicode.IsUpper(first) {
+		return false
+	}
+
+	next, _ := utf8.DecodeRuneInString(strings.TrimLeft(after, " \t\r\n\"'([{"))
+	return !unicode.IsLower(next)
+}
+
+// LookupLanguage finds a language by its name or code, it returns nil if there is none
+func LookupLanguage(nameOrCode string) *Language {
+	nameOrCode = strings.ToLower(nameOrCode)
+	if l, exists := Languages[nameOrCode]; exists {
+		return l
+	}
+	for _, l := range Languages {
+		if l.Code == nameOrCode {
+			return l
+		}
+	}
+	return nil
+}
+
+// DetectLanguage identifies the language of the text by comparing its character trigrams
+// with the profiles of the known languages. It returns nil if none of them matches.
+func DetectLanguage(text string) *Language {
+	if len(text) > languageDetectionSize {
+		text = strings.ToValidUTF8(text[:languageDetectionSize], "")
+	}
+	profile := trigramProfile(text)
+	if len(profile) == 0 {
+		return nil
+	}
+
+	// sort the names so ties are always broken the same way
+	names := make([]string, 0, len(Languages))
+	for name := range Languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var detected *Language
+	best := len(profile) * languageProfileSize
+	for _, name := range names {
+		l := Languages[name]
+		l.prepare()
+		distance := 0
+		for trigram, rank := range profile {
+			if r, exists := l.profile[trigram]; exists {
+				distance += abs(r - rank)
+			} else {
+				distance += languageProfileSize
+			}
+		}
+		if distance < best {
+			best = distance
+			detected = l
+		}
+	}
+	return detected
+}
+
+// trigramProfile ranks the most frequent character trigrams of the words in text
+func trigramProfile(text string) map[string]int {
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	trigrams := make([]string, 0, len(counts))
+	for trigram := range counts {
+		trigrams = append(trigrams, trigram)
+	}
+	sort.Slice(trigrams, func(i, j int) bool {
+		if counts[trigrams[i]] != counts[trigrams[j]] {
+			return counts[trigrams[i]] > counts[trigrams[j]]
+		}
+		return trigrams[i] < trigrams[j]
+	})
+	if len(trigrams) > languageProfileSize {
+		trigrams = trigrams[:languageProfileSize]
+	}
+
+	profile := make(map[string]int, len(trigrams))
+	for rank, trigram := range trigrams {
+		profile[trigram] = rank
+	}
+	return profile
+}
+
+// SetStopWords sets the words left out of the sentence vectors, overriding the ones of the language.
+// Pass nil to go back to the language stop words.
+func (bag *Bag) SetStopWords(words []string) {
+	if words == nil {
+		bag.stopWords = nil
+		return
+	}
+	bag.stopWords = make(map[string]bool, len(words))
+	for _, word := range words {
+		bag.stopWords[strings.ToLower(word)] = true
+	}
+}
+
+// SetSentenceTokenizer sets the function used to split the text into sentences,
+// overriding the rules of the language. Pass nil to go back to the default.
+func (bag *Bag) SetSentenceTokenizer(f func(text string) []string) {
+	bag.sentenceTokenizer = f
+}
+
+// resolveLanguage picks the language used for this run from the Language setting
+func (bag *Bag) resolveLanguage(text string) {
+	switch bag.Language {
+	case "":
+		bag.language = nil
+	case LANGUAGE_AUTO:
+		if text == "" {
+			text = strings.Join(bag.OriginalSentences, " ")
+		}
+		bag.language = DetectLanguage(text)
+	default:
+		bag.language = LookupLanguage(bag.Language)
+	}
+}
+
+func (bag *Bag) tokenizeSentences(text string) []string {
+	switch {
+	case bag.sentenceTokenizer != nil:
+		return bag.sentenceTokenizer(text)
+	case bag.language != nil:
+		return bag.language.TokenizeSentences(text)
+	}
+	return TokenizeSentences(text)
+}
+
+// splitBlock splits the text of a block, like a paragraph, into sentences.
+// The block ends a sentence, so unlike tokenizeSentences it keeps any text left after the last one.
+func (bag *Bag) splitBlock(text string) []string {
+	text = strings.TrimSpace(text)
+	sentences := bag.tokenizeSentences(text)
+
+	from := 0
+	for _, sentence := range sentences {
+		if at := strings.Index(text[from:], sentence); at >= 0 {
+			from += at + len(sentence)
+		}
+	}
+	if tail := strings.TrimSpace(text[from:]); tail != "" {
+		sentences = append(sentences, tail)
+	}
+	return sentences
+}
+
+func (bag *Bag) isStopWord(word string) bool {
+	switch {
+	case bag.stopWords != nil:
+		return bag.stopWords[word]
+	case bag.language != nil:
+		return bag.language.IsStopWord(word)
+	}
+	return false
+}
+
+func (bag *Bag) currentStemmer() func(word string) string {
+	if bag.stemmer == nil && bag.language != nil {
+		return bag.language.Stemmer
+	}
+	return bag.stemmer
+}
+
+// languageName returns the name of the language used in the last run, if any
+func (bag *Bag) languageName() string {
+	if bag.language == nil {
+		return ""
+	}
+	return bag.language.Name
+}
@@ -0,0 +1,137 @@
+package tldr
+
+// Built in languages. Samples are only used to train the trigram profiles for language detection.
+
+var english = &Language{
+	Name: "english",
+	Code: "en",
+	StopWords: []string{
+		"a", "about", "above", "after", "again", "against", "all", "also", "am", "an", "and", "any",
+		"are", "as", "at", "be", "because", "been", "before", "being", "below", "between", "both",
+		"but", "by", "can", "could", "did", "do", "does", "doing", "don't", "down", "during", "each",
+		"few", "for", "from", "further", "had", "has", "have", "having", "he", "her", "here", "hers",
+		"herself", "him", "himself", "his", "how", "i", "i'm", "if", "in", "into", "is", "it", "it's",
+		"its", "itself", "just", "me", "more", "most", "my", "myself", "no", "nor", "not", "now", "of",
+		"off", "on", "once", "only", "or", "other", "our", "ours", "ourselves", "out", "over", "own",
+		"same", "she", "should", "so", "some", "such", "than", "that", "the", "their", "theirs",
+		"them", "themselves", "then", "there", "these", "they", "this", "those", "through", "to",
+		"too", "under", "until", "up", "very", "was", "we", "were", "what", "when", "where", "which",
+		"while", "who", "whom", "why", "will", "with", "would", "you", "your", "yours", "yourself",
+		"yourselves",
+	},
+	Abbreviations: []string{
+		"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "vs", "etc", "e.g", "i.e", "inc", "ltd",
+		"co", "corp", "jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "oct", "nov",
+		"dec", "no", "fig", "approx", "dept", "est", "gen", "gov", "rep", "sen", "u.s",
+	},
+	Stemmer: StemEnglish,
+	sample: `The city council voted on Tuesday to approve the new budget, which includes more money for
+public transport and the repair of old roads. Several members said that the plan was the result of
+months of work with residents, who had asked for safer streets and better services in their
+neighbourhoods. The mayor thanked everyone for their patience and promised that the first projects
+would start before the end of the year. However, some critics argued that the council should have
+spent less on consultants and more on schools. They also warned that rising prices could make the
+work more expensive than expected. According to the report, the government will review the progress
+every three months and publish the findings online so that people can follow what is happening.
+Businesses in the area have welcomed the decision, saying that it would help them attract customers
+and create jobs for young people who are looking for work.`,
+}
+
+var german = &Language{
+	Name: "german",
+	Code: "de",
+	StopWords: []string{
+		"aber", "alle", "allem", "allen", "aller", "alles", "als", "also", "am", "an", "ander",
+		"andere", "anderem", "anderen", "anderer", "anderes", "auch", "auf", "aus", "bei", "bin",
+		"bis", "bist", "da", "damit", "dann", "das", "dass", "dein", "deine", "dem", "den", "der",
+		"des", "dich", "die", "dies", "diese", "diesem", "diesen", "dieser", "dieses", "dir", "doch",
+		"dort", "du", "durch", "ein", "eine", "einem", "einen", "einer", "eines", "er", "es", "euer",
+		"eure", "für", "hab", "habe", "haben", "hat", "hatte", "hatten", "hier", "hin", "hinter",
+		"ich", "ihm", "ihn", "ihnen", "ihr", "ihre", "im", "in", "ist", "jede", "jedem", "jeden",
+		"jeder", "jedes", "jetzt", "kann", "kein", "keine", "können", "man", "mein", "meine", "mich",
+		"mir", "mit", "muss", "nach", "nicht", "nichts", "noch", "nun", "nur", "ob", "oder", "ohne",
+		"sehr", "sein", "seine", "sich", "sie", "sind", "so", "soll", "sondern", "um", "und", "uns",
+		"unser", "unter", "viel", "vom", "von", "vor", "war", "waren", "warum", "was", "weil",
+		"welche", "wenn", "wer", "werden", "wie", "wieder", "will", "wir", "wird", "wo", "zu", "zum",
+		"zur", "über",
+	},
+	Abbreviations: []string{
+		"z.b", "d.h", "usw", "bzw", "ca", "dr", "prof", "nr", "str", "s", "u.a", "vgl", "evtl",
+		"ggf", "inkl", "bspw", "hr", "fr", "jh", "mio", "mrd",
+	},
+	Stemmer: StemGerman,
+	sample: `Der Stadtrat hat am Dienstag den neuen Haushalt beschlossen, der mehr Geld für den
+öffentlichen Nahverkehr und die Sanierung alter Straßen vorsieht. Mehrere Mitglieder sagten, dass
+der Plan das Ergebnis monatelanger Arbeit mit den Bürgern sei, die sich sicherere Straßen und
+bessere Dienstleistungen in ihren Vierteln gewünscht hatten. Die Bürgermeisterin dankte allen für
+ihre Geduld und versprach, dass die ersten Projekte noch vor dem Ende des Jahres beginnen würden.
+Einige Kritiker meinten jedoch, dass die Stadt weniger für Berater und mehr für die Schulen hätte
+ausgeben sollen. Sie warnten außerdem, dass steigende Preise die Arbeiten teurer machen könnten als
+erwartet. Laut dem Bericht wird die Regierung den Fortschritt alle drei Monate überprüfen und die
+Ergebnisse im Internet veröffentlichen, damit die Menschen verfolgen können, was geschieht. Die
+Unternehmen in der Gegend begrüßten die Entscheidung und erklärten, dass sie ihnen helfen werde,
+Kunden zu gewinnen und Arbeitsplätze für junge Leute zu schaffen.`,
+}
+
+var spanish = &Language{
+	Name: "spanish",
+	Code: "es",
+	StopWords: []string{
+		"a", "al", "algo", "algunas", "algunos", "ante", "antes", "como", "con", "contra", "cual",
+		"cuando", "de", "del", "desde", "donde", "durante", "e", "el", "ella", "ellas", "ellos", "en",
+		"entre", "era", "es", "esa", "esas", "ese", "eso", "esos", "esta", "estaba", "estado",
+		"estas", "este", "esto", "estos", "está", "están", "fue", "fueron", "ha", "había", "han",
+		"hasta", "hay", "la", "las", "le", "les", "lo", "los", "más", "me", "mi", "mucho", "muy",
+		"nada", "ni", "no", "nos", "nosotros", "o", "otra", "otros", "para", "pero", "poco", "por",
+		"porque", "que", "quien", "se", "sea", "ser", "si", "sido", "sin", "sobre", "son", "su",
+		"sus", "también", "tanto", "te", "tiene", "tienen", "todo", "todos", "tu", "un", "una",
+		"uno", "unos", "y", "ya", "yo", "él",
+	},
+	Abbreviations: []string{
+		"sr", "sra", "srta", "dr", "dra", "ud", "uds", "etc", "p.ej", "pág", "núm", "av", "avda",
+		"dña", "d", "ee.uu", "aprox",
+	},
+	Stemmer: StemSpanish,
+	sample: `El ayuntamiento aprobó el martes el nuevo presupuesto, que incluye más dinero para el
+transporte público y la reparación de las calles antiguas. Varios concejales dijeron que el plan
+era el resultado de meses de trabajo con los vecinos, quienes habían pedido calles más seguras y
+mejores servicios en sus barrios. La alcaldesa agradeció a todos su paciencia y prometió que los
+primeros proyectos comenzarían antes de que termine el año. Sin embargo, algunos críticos
+afirmaron que la ciudad debería haber gastado menos en asesores y más en las escuelas. También
+advirtieron que la subida de los precios podría encarecer las obras más de lo previsto. Según el
+informe, el gobierno revisará los avances cada tres meses y publicará los resultados en internet
+para que la gente pueda seguir lo que está pasando. Las empresas de la zona celebraron la decisión
+y aseguraron que les ayudará a atraer clientes y a crear empleo para los jóvenes que buscan
+trabajo.`,
+}
+
+var indonesian = &Language{
+	Name: "indonesian",
+	Code: "id",
+	StopWords: []string{
+		"ada", "adalah", "agar", "akan", "aku", "anda", "antara", "apa", "atau", "bagi", "bahwa",
+		"banyak", "baru", "belum", "bisa", "dalam", "dan", "dapat", "dari", "dengan", "di", "dia",
+		"hanya", "harus", "hingga", "ia", "ini", "itu", "jika", "juga", "kami", "kamu", "karena",
+		"ke", "kita", "lagi", "lain", "lebih", "maka", "masih", "mereka", "meski", "oleh", "pada",
+		"para", "saat", "sama", "sampai", "sangat", "saya", "sebagai", "sebelum", "sedang",
+		"sehingga", "sejak", "semua", "sendiri", "seperti", "serta", "setelah", "sudah", "tak",
+		"telah", "tentang", "tersebut", "tetapi", "tidak", "untuk", "yaitu", "yang",
+	},
+	Abbreviations: []string{
+		"dll", "dsb", "dst", "bpk", "ibu", "jl", "no", "tn", "ny", "prof", "dr", "drs", "ir", "hlm",
+		"kec", "kab",
+	},
+	Stemmer: StemIndonesian,
+	sample: `Dewan kota pada hari Selasa menyetujui anggaran baru yang mencakup lebih banyak dana
+untuk transportasi umum dan perbaikan jalan-jalan lama. Beberapa anggota dewan mengatakan bahwa
+rencana tersebut merupakan hasil kerja berbulan-bulan bersama warga, yang telah meminta jalan yang
+lebih aman dan pelayanan yang lebih baik di lingkungan mereka. Wali kota berterima kasih kepada
+semua orang atas kesabaran mereka dan berjanji bahwa proyek pertama akan dimulai sebelum akhir
+tahun. Namun, sejumlah pengkritik berpendapat bahwa pemerintah kota seharusnya mengeluarkan lebih
+sedikit uang untuk konsultan dan lebih banyak untuk sekolah. Mereka juga memperingatkan bahwa
+kenaikan harga dapat membuat pekerjaan menjadi lebih mahal daripada yang diperkirakan. Menurut
+laporan itu, pemerintah akan meninjau kemajuan setiap tiga bulan dan menerbitkan hasilnya di
+internet sehingga masyarakat dapat mengikuti apa yang sedang terjadi. Para pengusaha di daerah itu
+menyambut baik keputusan tersebut dan mengatakan bahwa hal itu akan membantu mereka menarik
+pelanggan dan menciptakan lapangan kerja bagi anak muda yang sedang mencari pekerjaan.`,
+}
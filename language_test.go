@@ -0,0 +1,90 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Languages", func() {
+	Describe("DetectLanguage()", func() {
+		It("Should detect the built in languages", func() {
+			Expect(DetectLanguage(text).Name).To(Equal("english"))
+			Expect(DetectLanguage("Der schnelle braune Fuchs springt über den faulen Hund.").Name).To(Equal("german"))
+			Expect(DetectLanguage("El rápido zorro marrón salta sobre el perro perezoso.").Name).To(Equal("spanish"))
+			Expect(DetectLanguage("Rubah coklat yang cepat melompati anjing yang malas itu.").Name).To(Equal("indonesian"))
+		})
+
+		It("Should return nil when there is nothing to detect", func() {
+			Expect(DetectLanguage("")).To(BeNil())
+			Expect(DetectLanguage("1234 5678 !!")).To(BeNil())
+		})
+	})
+
+	Describe("LookupLanguage()", func() {
+		It("Should find languages by name or code", func() {
+			Expect(LookupLanguage("German").Code).To(Equal("de"))
+			Expect(LookupLanguage("id").Name).To(Equal("indonesian"))
+			Expect(LookupLanguage("xx")).To(BeNil())
+		})
+	})
+
+	Describe("Language.TokenizeSentences()", func() {
+		It("Should not end sentences at abbreviations and initials", func() {
+			sentences := LookupLanguage("en").TokenizeSentences("Mr. Smith met Dr. J. Watson yesterday. They talked e.g. about cats. It was fun!")
+			Expect(sentences).To(Equal([]string{
+				"Mr. Smith met Dr. J. Watson yesterday.",
+				"They talked e.g. about cats.",
+				"It was fun!",
+			}))
+		})
+
+		It("Should use the abbreviations of each language", func() {
+			sentences := LookupLanguage("de").TokenizeSentences("Wir essen z.B. Äpfel und Birnen. Das ist gut.")
+			Expect(sentences).To(HaveLen(2))
+		})
+	})
+
+	Describe("Bag with a language", func() {
+		var bag *Bag
+
+		BeforeEach(func() {
+			bag = New()
+		})
+
+		It("Should report the detected language in the summary", func() {
+			bag.Language = LANGUAGE_AUTO
+			summary, err := bag.SummarizeDetailed(text, 3)
+			Expect(err).To(BeNil())
+			Expect(summary.Language).To(Equal("english"))
+			Expect(summary.Sentences).To(HaveLen(3))
+		})
+
+		It("Should remove stop words and stem the words", func() {
+			bag.Language = "spanish"
+			_, err := bag.Summarize("El gobierno aprobó las leyes. Las leyes nuevas son buenas. El pueblo celebra la aprobación.", 1)
+			Expect(err).To(BeNil())
+			Expect(bag.BagOfWordsPerSentence[0]).To(Equal([]string{"gobiern", "aprob", "ley"}))
+			Expect(bag.Dict).NotTo(HaveKey("el"))
+		})
+
+		It("Should prefer stop words and sentence tokenizers set on the bag", func() {
+			bag.Language = "english"
+			bag.SetStopWords([]string{"Cats"})
+			bag.SetSentenceTokenizer(func(text string) []string {
+				return []string{"the cats sleep", "the dogs bark", "the birds sing"}
+			})
+			_, err := bag.Summarize("ignored", 1)
+			Expect(err).To(BeNil())
+			Expect(bag.OriginalSentences).To(HaveLen(3))
+			Expect(bag.BagOfWordsPerSentence[0]).To(Equal([]string{"the", "sleep"}))
+		})
+
+		It("Should not use any language by default", func() {
+			summary, err := bag.SummarizeDetailed(text, 3)
+			Expect(err).To(BeNil())
+			Expect(summary.Language).To(BeEmpty())
+		})
+	})
+})
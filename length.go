@@ -0,0 +1,182 @@
+package tldr
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// most candidates considered when selecting sentences within a budget, taken from the highest ranks
+	budgetCandidates = 200
+	// most states of the dynamic programming of a budget, the budgets are scaled down to fit in it
+	budgetStates = 1 << 17
+)
+
+// SetTokenCounter sets the function counting the tokens of a sentence for MaxTokens,
+// for example the tokenizer of a language model. Pass nil to go back to EstimateTokens.
+func (bag *Bag) SetTokenCounter(f func(text string) int) {
+	bag.tokenCounter = f
+}
+
+// EstimateTokens estimates the number of tokens of text for the BPE tokenizers of language models,
+// without their vocabulary: a token for every 4 letters or digits of a word, and for every other symbol
+func EstimateTokens(text string) int {
+	tokens, letters := 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || r == '\'':
+			letters++
+			continue
+		case !unicode.IsSpace(r):
+			tokens++
+		}
+		tokens += (letters + 3) / 4
+		letters = 0
+	}
+	return tokens + (letters+3)/4
+}
+
+// countTokens counts the tokens of text with the token counter of the bag
+func (bag *Bag) countTokens(text string) int {
+	if bag.tokenCounter != nil {
+		return bag.tokenCounter(text)
+	}
+	return EstimateTokens(text)
+}
+
+// selectSentences returns the index of the sentences of the summary from the ranks, in no particular order.
+// With Ratio, num is that share of the sentences of the text, ranked or not. Without MaxWords and MaxTokens, the top num ranks are selected,
+// and num is 1 if it is invalid. Otherwise, see selectWithinBudget.
+func (bag *Bag) selectSentences(num int) []int {
+	if bag.Ratio > 0 {
+		num = min(int(math.Ceil(bag.Ratio*float64(len(bag.OriginalSentences)))), len(bag.Ranks))
+	}
+	if bag.MaxWords > 0 || bag.MaxTokens > 0 {
+		return bag.selectWithinBudget(num)
+	}
+
+	// guard so it won't crash but return only the highest rank sentence
+	// if num is invalid
+	if num > len(bag.Ranks) || num < 1 {
+		num = 1
+	}
+	return append([]int(nil), bag.Ranks[:num]...)
+}
+
+// selectWithinBudget selects at most num sentences, or any number of them if num is invalid,
+// fitting in MaxWords and MaxTokens with the highest total score. It solves the knapsack problem
+// over the highest ranked sentences, with the budgets scaled down and the costs rounded up if they are too large,
+// so the sentences selected always fit but may not be the best ones in that case.
+func (bag *Bag) selectWithinBudget(num int) []int {
+	budgets := []int{bag.MaxWords, bag.MaxTokens}
+
+	// the cost of every candidate in words and tokens, 0 for a budget not set
+	var candidates []int
+	var costs [][2]int
+	for _, i := range bag.Ranks {
+		if len(candidates) == budgetCandidates {
+			break
+		}
+		if i < 0 || i >= len(bag.OriginalSentences) {
+			continue
+		}
+		sentence := bag.OriginalSentences[i]
+		var cost [2]int
+		if bag.MaxWords > 0 {
+			cost[0] = len(strings.Fields(sentence))
+		}
+		if bag.MaxTokens > 0 {
+			cost[1] = bag.countTokens(sentence)
+		}
+		if cost[0]+cost[1] == 0 || (budgets[0] > 0 && cost[0] > budgets[0]) || (budgets[1] > 0 && cost[1] > budgets[1]) {
+			continue
+		}
+		candidates = append(candidates, i)
+		costs = append(costs, cost)
+	}
+	if num < 1 || num > len(candidates) {
+		num = 0 // no limit on the number of sentences
+	}
+
+	// scale the budgets down so the states fit in budgetStates, and round the costs up
+	dims := 0
+	for _, budget := range budgets {
+		if budget > 0 {
+			dims++
+		}
+	}
+	largest := int(math.Floor(math.Pow(float64(budgetStates)/float64(num+1), 1/float64(dims)))) - 1
+	if largest < 1 {
+		largest = 1
+	}
+	for d, budget := range budgets {
+		if budget > largest {
+			scale := (budget + largest - 1) / largest
+			budgets[d] = budget / scale
+			for c := range costs {
+				costs[c][d] = (costs[c][d] + scale - 1) / scale
+			}
+		}
+	}
+
+	// a state is the number of sentences selected, the words and the tokens spent, flattened into an index
+	counts := 1
+	if num > 0 {
+		counts = num + 1
+	}
+	wordStride, tokenStride := counts, counts*(budgets[0]+1)
+	states := tokenStride * (budgets[1] + 1)
+	best := make([]float64, states)
+	for state := range best {
+		best[state] = math.Inf(-1)
+	}
+	best[0] = 0
+
+	offsets := make([]int, len(candidates))
+	taken := make([][]uint64, len(candidates))
+	for c, i := range candidates {
+		cost := costs[c]
+		if cost[0] > budgets[0] || cost[1] > budgets[1] {
+			continue
+		}
+		count := 0
+		if num > 0 {
+			count = 1
+		}
+		offsets[c] = count + cost[0]*wordStride + cost[1]*tokenStride
+		taken[c] = make([]uint64, (states+63)/64)
+		// higher ranks win ties, and sentences scored 0 are still worth selecting
+		score := float64(len(candidates)-c) * 1e-9
+		if i < len(bag.scores) {
+			score += bag.scores[i]
+		}
+		// every dimension goes down, so each candidate is selected once at most
+		for t := budgets[1] - cost[1]; t >= 0; t-- {
+			for w := budgets[0] - cost[0]; w >= 0; w-- {
+				for k := counts - 1 - count; k >= 0; k-- {
+					state := k + w*wordStride + t*tokenStride
+					if next := state + offsets[c]; best[state]+score > best[next] {
+						best[next] = best[state] + score
+						taken[c][next/64] |= 1 << (next % 64)
+					}
+				}
+			}
+		}
+	}
+
+	end := 0
+	for state := range best {
+		if best[state] > best[end] {
+			end = state
+		}
+	}
+	idx := []int{}
+	for c := len(candidates) - 1; c >= 0; c-- {
+		if taken[c] != nil && taken[c][end/64]&(1<<(end%64)) != 0 {
+			idx = append(idx, candidates[c])
+			end -= offsets[c]
+		}
+	}
+	return idx
+}
@@ -0,0 +1,124 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+)
+
+var _ = Describe("Length limits", func() {
+	// ranked in order, so their scores are 1, 0.75, 0.5 and 0.25
+	sentences := []string{
+		"One two three four five six seven eight nine ten.",
+		"Apples grow on tall green trees.",
+		"Rivers run down to the sea.",
+		"Birds sing loudly.",
+	}
+	inOrder := func(bag *Bag) {
+		bag.Algorithm = "custom"
+		bag.SetCustomAlgorithm(func(e []*Edge) []int {
+			return []int{0, 1, 2, 3}
+		})
+	}
+	words := func(sums []string) int {
+		return len(strings.Fields(strings.Join(sums, " ")))
+	}
+
+	It("Should keep a share of the sentences", func() {
+		bag := New()
+		bag.Ratio = 0.2
+		sums, err := bag.Summarize(text, 1)
+		Expect(err).To(BeNil())
+		Expect(len(sums)).To(Equal((len(bag.OriginalSentences)*2 + 9) / 10))
+	})
+
+	It("Should keep a share of all the sentences, not only the ranked ones", func() {
+		bag := New()
+		bag.Algorithm = "custom"
+		bag.SetCustomAlgorithm(func(e []*Edge) []int {
+			return []int{2, 0}
+		})
+		bag.Ratio = 0.5
+		sums, err := bag.SummarizeSentences(sentences, 1)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal([]string{sentences[0], sentences[2]}))
+
+		// but never more than the ranked ones
+		bag.Ratio = 1
+		sums, err = bag.SummarizeSentences(sentences, 1)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal([]string{sentences[0], sentences[2]}))
+	})
+
+	It("Should select the sentences with the highest total score within the words", func() {
+		bag := New()
+		inOrder(bag)
+		bag.MaxWords = 12
+		sums, err := bag.SummarizeSentences(sentences, 4)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal(sentences[1:3]))
+
+		bag.MaxWords = 13
+		sums, err = bag.SummarizeSentences(sentences, 4)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal([]string{sentences[0], sentences[3]}))
+	})
+
+	It("Should select at most the number of sentences asked for", func() {
+		bag := New()
+		inOrder(bag)
+		bag.MaxWords = 100
+		sums, err := bag.SummarizeSentences(sentences, 2)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal(sentences[:2]))
+
+		sums, err = bag.SummarizeSentences(sentences, 0)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal(sentences))
+	})
+
+	It("Should count the tokens with the token counter", func() {
+		bag := New()
+		inOrder(bag)
+		bag.MaxTokens = 59
+		bag.SetTokenCounter(func(text string) int {
+			return len(text)
+		})
+		sums, err := bag.SummarizeSentences(sentences, 4)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal(sentences[1:3]))
+
+		bag.MaxWords = 5
+		sums, err = bag.SummarizeSentences(sentences, 4)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal([]string{sentences[3]}))
+	})
+
+	It("Should stay within large budgets", func() {
+		bag := New()
+		bag.MaxWords = 150
+		bag.MaxTokens = 100000
+		sums, err := bag.Summarize(text, 0)
+		Expect(err).To(BeNil())
+		Expect(sums).NotTo(BeEmpty())
+		Expect(words(sums)).To(BeNumerically("<=", 150))
+		Expect(words(sums)).To(BeNumerically(">", 100))
+	})
+
+	It("Should select nothing when no sentence fits", func() {
+		bag := New()
+		bag.MaxWords = 2
+		sums, err := bag.SummarizeSentences(sentences, 4)
+		Expect(err).To(BeNil())
+		Expect(sums).To(BeEmpty())
+	})
+
+	It("Should estimate the tokens of a text", func() {
+		Expect(EstimateTokens("")).To(Equal(0))
+		Expect(EstimateTokens("Hello, world!")).To(Equal(6))
+		Expect(EstimateTokens("internationalization")).To(Equal(5))
+	})
+})
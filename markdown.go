@@ -0,0 +1,527 @@
+package tldr
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MarkdownSummary is the result of summarizing a Markdown document
+type MarkdownSummary struct {
+	Title     string // the first level one heading, or the first heading if none
+	Language  string
+	Sentences []*MarkdownSentence // selected sentences, in document order
+}
+
+// MarkdownSentence is a sentence selected from a Markdown document.
+// Its Start and End are byte offsets in the Markdown source.
+type MarkdownSentence struct {
+	*Sentence
+	Markdown string   // the sentence as written in the Markdown source
+	Headings []string // headings of the sections holding the sentence, outermost first
+}
+
+var (
+	atxHeading      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+|$)`)
+	atxClosing      = regexp.MustCompile(`(?:^|[ \t]+)#+[ \t]*$`)
+	setextUnderline = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	thematicBreak   = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	codeFence       = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	tableDelimiter  = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	linkDefinition  = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:[ \t]*\S`)
+	htmlBlock       = regexp.MustCompile(`(?i)^ {0,3}(?:<!--|<\?|<!\[CDATA\[|</?(?:address|article|aside|blockquote|center|details|dialog|div|dl|fieldset|figure|footer|form|h[1-6]|header|hr|iframe|img|nav|ol|p|picture|pre|script|section|style|summary|table|ul|video)(?:[\s/>]|$))`)
+	listItem        = regexp.MustCompile(`^[ \t]*(?:[-*+]|\d{1,9}[.)])(?:[ \t]+(?:\[[ xX]\][ \t]+)?|$)`)
+	blockQuote      = regexp.MustCompile(`^ {0,3}>[ \t]?`)
+	autolink        = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*|[^\s<>@]+@[^\s<>@]+)$`)
+	inlineHTML      = regexp.MustCompile(`^</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>$|^<!--[\s\S]*?-->$`)
+)
+
+const asciiPunctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// SummarizeMarkdown summarizes a Markdown document to num sentences.
+// Code blocks, tables, HTML blocks and link definitions are skipped, headings are never selected
+// but give the sections of the sentences, and inline markup is stripped before tokenization.
+// When HeadingWeight is set, sentences sharing words with their section headings rank higher.
+func (bag *Bag) SummarizeMarkdown(markdown string, num int) (*MarkdownSummary, error) {
+	summary := &MarkdownSummary{}
+	preprocessors := append([]Preprocessor{StripMarkdownInline}, bag.preprocessors...)
+
+	var texts []string
+	var offsets [][]int
+	var sections [][]string
+	var headings []string
+	var levels []int
+	titleLevel := 0
+	for _, block := range parseMarkdown(markdown) {
+		pre := Preprocess(block.text, preprocessors...)
+		if block.heading > 0 {
+			heading := collapseSpaces(pre.Text)
+			for len(levels) > 0 && levels[len(levels)-1] >= block.heading {
+				levels = levels[:len(levels)-1]
+				headings = headings[:len(headings)-1]
+			}
+			levels = append(levels, block.heading)
+			headings = append(headings, heading)
+			if titleLevel != 1 && (titleLevel == 0 || block.heading == 1) {
+				summary.Title = heading
+				titleLevel = block.heading
+			}
+			continue
+		}
+
+		blockOffsets := make([]int, len(pre.Text)+1)
+		for i := range blockOffsets {
+			blockOffsets[i] = block.offsets[pre.OriginalOffset(i)]
+		}
+		texts = append(texts, pre.Text)
+		offsets = append(offsets, blockOffsets)
+		sections = append(sections, append([]string(nil), headings...))
+	}
+
+	source := joinBlocks(markdown, texts, offsets)
+	bag.resolveLanguage(source.Text)
+	summary.Language = bag.languageName()
+
+	var sentences []string
+	var owners []int
+	for i, text := range texts {
+		for _, sentence := range bag.splitBlock(text) {
+			sentences = append(sentences, sentence)
+			owners = append(owners, i)
+		}
+	}
+
+	var boosts []float64
+	if bag.HeadingWeight != 0 {
+		boosts = bag.headingBoosts(sentences, owners, sections)
+	}
+
+	idx, err := bag.summarizeSentences(source, sentences, boosts, num)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sentence := range bag.summarySentences(idx) {
+		selected := &MarkdownSentence{
+			Sentence: sentence,
+			Headings: sections[owners[sentence.Index]],
+		}
+		if sentence.Start >= 0 {
+			for sentence.Start > 0 && strings.IndexByte("*_~`[!", markdown[sentence.Start-1]) >= 0 {
+				sentence.Start--
+			}
+			selected.Markdown = strings.TrimRightFunc(markdown[sentence.Start:sentence.End], unicode.IsSpace)
+			sentence.End = sentence.Start + len(selected.Markdown)
+		}
+		summary.Sentences = append(summary.Sentences, selected)
+	}
+	return summary, nil
+}
+
+// headingBoosts gives every sentence HeadingWeight times the share of the words of its section headings it has,
+// sections holds the headings of every owner of the sentences
+func (bag *Bag) headingBoosts(sentences []string, owners []int, sections [][]string) []float64 {
+	boosts := make([]float64, len(sentences))
+	for i, sentence := range sentences {
+		headingWords := make(map[string]bool)
+		for _, heading := range sections[owners[i]] {
+			for _, word := range bag.normalizeWords(bag.wordTokenizer(heading)) {
+				headingWords[word] = true
+			}
+		}
+		if len(headingWords) == 0 {
+			continue
+		}
+
+		shared := make(map[string]bool)
+		for _, word := range bag.normalizeWords(bag.wordTokenizer(sentence)) {
+			if headingWords[word] {
+				shared[word] = true
+			}
+		}
+		boosts[i] = bag.HeadingWeight * float64(len(shared)) / float64(len(headingWords))
+	}
+	return boosts
+}
+
+// joinBlocks joins the texts of the blocks with blank lines into a text mapped to the Markdown source
+func joinBlocks(markdown string, texts []string, offsets [][]int) *PreprocessedText {
+	var b strings.Builder
+	joined := make([]int, 0, len(markdown)+1)
+	end := 0
+	for i, text := range texts {
+		if i > 0 {
+			b.WriteString("\n\n")
+			joined = append(joined, end, end)
+		}
+		b.WriteString(text)
+		joined = append(joined, offsets[i][:len(text)]...)
+		end = offsets[i][len(text)]
+	}
+	return &PreprocessedText{
+		Original: markdown,
+		Text:     b.String(),
+		offsets:  append(joined, end),
+	}
+}
+
+// markdownBlock is a paragraph, list item or heading of a Markdown document
+type markdownBlock struct {
+	heading int    // level of the heading, 0 if the block is not one
+	text    string // text of the block, without the block markup
+	offsets []int  // offset in the source of every byte of text and its end
+}
+
+type markdownLine struct {
+	start int
+	text  string
+}
+
+// markdownParser splits a Markdown document into blocks, line by line
+type markdownParser struct {
+	blocks    []*markdownBlock
+	paragraph *offsetBuilder
+	listItem  bool // the open paragraph is a list item
+	inList    bool // the last block was a list item, so indented lines continue it
+}
+
+// parseMarkdown returns the blocks of prose and headings of a Markdown document,
+// leaving out front matter, code blocks, tables, HTML blocks, link definitions and thematic breaks
+func parseMarkdown(markdown string) []*markdownBlock {
+	var lines []markdownLine
+	for start := 0; start < len(markdown); {
+		end := strings.IndexByte(markdown[start:], '\n')
+		if end < 0 {
+			end = len(markdown) - start
+		}
+		lines = append(lines, markdownLine{start, strings.TrimSuffix(markdown[start:start+end], "\r")})
+		start += end + 1
+	}
+
+	p := &markdownParser{}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		blank := strings.TrimSpace(line.text) == ""
+
+		switch {
+		case i == 0 && strings.TrimSpace(line.text) == "---":
+			// front matter, a thematic break if it is never closed
+			if end := skipUntil(lines, 1, func(l string) bool { t := strings.TrimSpace(l); return t == "---" || t == "..." }); end < len(lines) {
+				i = end
+			}
+
+		case blank:
+			p.flush()
+
+		case codeFence.MatchString(line.text):
+			p.flush()
+			fence := codeFence.FindStringSubmatch(line.text)[1]
+			i = skipUntil(lines, i+1, func(l string) bool {
+				l = strings.TrimLeft(l, " ")
+				return strings.HasPrefix(l, fence) && strings.Trim(l, fence[:1]+" \t") == ""
+			})
+
+		case indentation(line.text) >= 4 && p.paragraph == nil && !p.inList:
+			// indented code, up to the next line indented less that is not blank
+			for i+1 < len(lines) && (strings.TrimSpace(lines[i+1].text) == "" || indentation(lines[i+1].text) >= 4) {
+				i++
+			}
+
+		case atxHeading.MatchString(line.text):
+			p.flush()
+			p.inList = false
+			match := atxHeading.FindStringSubmatchIndex(line.text)
+			text := line.text[match[1]:]
+			if closing := atxClosing.FindStringIndex(text); closing != nil {
+				text = text[:closing[0]]
+			}
+			b := newOffsetBuilder(len(text))
+			b.keep(text, line.start+match[1])
+			text, offsets := b.done(line.start + match[1] + len(text))
+			p.blocks = append(p.blocks, &markdownBlock{
+				heading: match[3] - match[2],
+				text:    text,
+				offsets: offsets,
+			})
+
+		case p.paragraph != nil && !p.listItem && setextUnderline.MatchString(line.text):
+			level := 2
+			if strings.TrimSpace(line.text)[0] == '=' {
+				level = 1
+			}
+			p.flush()
+			p.blocks[len(p.blocks)-1].heading = level
+
+		case thematicBreak.MatchString(line.text):
+			p.flush()
+			p.inList = false
+
+		case strings.Contains(line.text, "|") && i+1 < len(lines) &&
+			strings.Contains(lines[i+1].text, "|") && tableDelimiter.MatchString(lines[i+1].text):
+			p.flush()
+			p.inList = false
+			i = skipUntil(lines, i+2, func(l string) bool { return !strings.Contains(l, "|") }) - 1
+
+		case p.paragraph == nil && linkDefinition.MatchString(line.text):
+
+		case p.paragraph == nil && htmlBlock.MatchString(line.text):
+			p.inList = false
+			i = skipUntil(lines, i+1, func(l string) bool { return strings.TrimSpace(l) == "" })
+
+		case listItem.MatchString(line.text):
+			p.flush()
+			marker := listItem.FindStringIndex(line.text)[1]
+			p.addLine(markdownLine{line.start + marker, line.text[marker:]})
+			p.listItem = true
+			p.inList = true
+
+		case blockQuote.MatchString(line.text):
+			text, at := line.text, line.start
+			for blockQuote.MatchString(text) {
+				marker := blockQuote.FindStringIndex(text)[1]
+				text, at = text[marker:], at+marker
+			}
+			if strings.TrimSpace(text) == "" {
+				p.flush()
+				continue
+			}
+			p.addLine(markdownLine{at, text})
+
+		default:
+			if p.paragraph == nil && indentation(line.text) < 4 {
+				p.inList = false
+			}
+			p.addLine(line)
+		}
+	}
+	p.flush()
+	return p.blocks
+}
+
+// addLine adds a line to the open paragraph, or opens one
+func (p *markdownParser) addLine(line markdownLine) {
+	text := strings.TrimLeft(line.text, " \t")
+	at := line.start + len(line.text) - len(text)
+	text = strings.TrimRight(text, " \t")
+	if text == "" {
+		return
+	}
+	if p.paragraph == nil {
+		p.paragraph = newOffsetBuilder(len(text))
+	} else {
+		p.paragraph.keep("\n", at-1)
+	}
+	p.paragraph.keep(text, at)
+}
+
+// flush closes the open paragraph
+func (p *markdownParser) flush() {
+	if p.paragraph == nil {
+		return
+	}
+	text, offsets := p.paragraph.done(p.paragraph.offsets[len(p.paragraph.offsets)-1] + 1)
+	p.blocks = append(p.blocks, &markdownBlock{
+		text:    text,
+		offsets: offsets,
+	})
+	p.paragraph = nil
+	p.listItem = false
+}
+
+// skipUntil returns the index of the first line from i on that stop is true for, or the number of lines
+func skipUntil(lines []markdownLine, i int, stop func(line string) bool) int {
+	for ; i < len(lines); i++ {
+		if stop(lines[i].text) {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+// indentation counts the columns of the leading whitespace of a line, tabs stop every 4 columns
+func indentation(line string) int {
+	columns := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			columns++
+		case '\t':
+			columns += 4 - columns%4
+		default:
+			return columns
+		}
+	}
+	return columns
+}
+
+// StripMarkdownInline removes inline Markdown markup: emphasis, code spans, links, images,
+// autolinks, HTML tags and backslash escapes, keeping the text they hold and the alternative text of images
+func StripMarkdownInline(text string) (string, []int) {
+	b := newOffsetBuilder(len(text))
+	stripInline(b, text, 0, len(text))
+	return b.done(len(text))
+}
+
+func stripInline(b *offsetBuilder, text string, from, to int) {
+	for i := from; i < to; {
+		switch c := text[i]; c {
+		case '\\':
+			if i+1 < to && strings.IndexByte(asciiPunctuation, text[i+1]) >= 0 {
+				b.keep(text[i+1:i+2], i+1)
+				i += 2
+				continue
+			}
+
+		case '`':
+			n := runLength(text, i, to)
+			if start, end, next, ok := codeSpan(text, i+n, to, n); ok {
+				b.keep(text[start:end], start)
+				i = next
+				continue
+			}
+			b.keep(text[i:i+n], i)
+			i += n
+			continue
+
+		case '!', '[':
+			if labelStart, labelEnd, end, ok := markdownLink(text, i, to); ok {
+				stripInline(b, text, labelStart, labelEnd)
+				i = end
+				continue
+			}
+
+		case '<':
+			if end := strings.IndexByte(text[i:to], '>'); end > 0 {
+				if inner := text[i+1 : i+end]; autolink.MatchString(inner) {
+					b.keep(inner, i+1)
+					i += end + 1
+					continue
+				}
+				if inlineHTML.MatchString(text[i : i+end+1]) {
+					i += end + 1
+					continue
+				}
+			}
+
+		case '*', '_', '~':
+			n := runLength(text, i, to)
+			if isEmphasis(text, i, i+n, to) {
+				i += n
+				continue
+			}
+			b.keep(text[i:i+n], i)
+			i += n
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(text[i:to])
+		b.keep(text[i:i+size], i)
+		i += size
+	}
+}
+
+// runLength counts how many times the byte at i repeats from i on
+func runLength(text string, i, to int) int {
+	n := 1
+	for i+n < to && text[i+n] == text[i] {
+		n++
+	}
+	return n
+}
+
+// codeSpan finds the content of a code span opened by n backticks right before from,
+// without the single space padding it, and where the span ends
+func codeSpan(text string, from, to, n int) (int, int, int, bool) {
+	for i := from; i < to; {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		m := runLength(text, i, to)
+		if m == n {
+			start, end := from, i
+			content := text[start:end]
+			if len(content) > 2 && content[0] == ' ' && content[len(content)-1] == ' ' && strings.TrimSpace(content) != "" {
+				start, end = start+1, end-1
+			}
+			return start, end, i + m, true
+		}
+		i += m
+	}
+	return 0, 0, 0, false
+}
+
+// markdownLink finds the label of a link or image starting at i, followed by a destination
+// in parentheses or a reference in brackets, and where the whole link ends
+func markdownLink(text string, i, to int) (int, int, int, bool) {
+	labelStart := i + 1
+	if text[i] == '!' {
+		if i+1 >= to || text[i+1] != '[' {
+			return 0, 0, 0, false
+		}
+		labelStart++
+	}
+	labelEnd := closingBracket(text, labelStart, to, '[', ']')
+	if labelEnd < 0 || labelEnd+1 >= to {
+		return 0, 0, 0, false
+	}
+
+	var end int
+	switch text[labelEnd+1] {
+	case '(':
+		end = closingBracket(text, labelEnd+2, to, '(', ')')
+	case '[':
+		end = closingBracket(text, labelEnd+2, to, '[', ']')
+	default:
+		return 0, 0, 0, false
+	}
+	if end < 0 {
+		return 0, 0, 0, false
+	}
+	return labelStart, labelEnd, end + 1, true
+}
+
+// closingBracket finds the bracket closing the one right before from, skipping nested and escaped ones
+func closingBracket(text string, from, to int, open, close byte) int {
+	depth := 0
+	for i := from; i < to; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// isEmphasis tells if the run of *, _ or ~ from start to end opens or closes emphasis or a strikethrough
+func isEmphasis(text string, start, end, to int) bool {
+	if text[start] == '~' && end-start < 2 {
+		return false
+	}
+	before, after := ' ', ' '
+	if start > 0 {
+		before, _ = utf8.DecodeLastRuneInString(text[:start])
+	}
+	if end < to {
+		after, _ = utf8.DecodeRuneInString(text[end:to])
+	}
+	opens := !unicode.IsSpace(after)
+	closes := !unicode.IsSpace(before)
+	if opens && closes && (isWordRune(before) && isWordRune(after)) {
+		// inside a word, like snake_case or 2*3
+		return false
+	}
+	return opens || closes
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
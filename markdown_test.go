@@ -0,0 +1,109 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+)
+
+const markdown = `---
+title: tldr
+---
+# The *tldr* package
+
+The tldr package is a **summarizer** for [Go](https://go.dev) programs. The summarizer ranks sentences of the text with ` + "`LexRank`" + `.
+
+` + "```go" + `
+bag := tldr.New()
+result, _ := bag.Summarize(text, 3)
+` + "```" + `
+
+## Installation
+
+Install the package with go get and import the summarizer.
+
+    go get github.com/didasy/tldr
+
+| Setting | Default |
+|---------|---------|
+| Damping | 0.85 |
+
+- The summarizer keeps the sentences in the order of the text.
+- Sentences of the text are nodes of a graph.
+
+[Go]: https://go.dev
+`
+
+var _ = Describe("Markdown", func() {
+	Describe("StripMarkdownInline()", func() {
+		It("Should keep the text of the markup", func() {
+			stripped, offsets := StripMarkdownInline("A **bold** _move_, see [the docs](https://x.y \"Docs\"), ![logo](l.png) and `code`.")
+			Expect(stripped).To(Equal("A bold move, see the docs, logo and code."))
+			Expect(offsets).To(HaveLen(len(stripped) + 1))
+		})
+
+		It("Should leave words with underscores and lone asterisks alone", func() {
+			stripped, _ := StripMarkdownInline("Use snake_case, 2 * 3 and 2*3 but not \\*this\\*.")
+			Expect(stripped).To(Equal("Use snake_case, 2 * 3 and 2*3 but not *this*."))
+		})
+
+		It("Should remove HTML tags and keep autolinks", func() {
+			stripped, _ := StripMarkdownInline("Mail <me@example.com> or <b>visit</b> <https://example.com>.")
+			Expect(stripped).To(Equal("Mail me@example.com or visit https://example.com."))
+		})
+	})
+
+	Describe("SummarizeMarkdown()", func() {
+		It("Should skip code, tables and front matter and keep the Markdown of the sentences", func() {
+			summary, err := New().SummarizeMarkdown(markdown, 5)
+			Expect(err).To(BeNil())
+			Expect(summary.Title).To(Equal("The tldr package"))
+			Expect(summary.Sentences).To(HaveLen(5))
+
+			texts := []string{}
+			for _, sentence := range summary.Sentences {
+				texts = append(texts, sentence.Text)
+				Expect(sentence.Text).NotTo(ContainSubstring("bag :="))
+				Expect(sentence.Text).NotTo(ContainSubstring("Damping"))
+				Expect(sentence.Text).NotTo(ContainSubstring("title:"))
+				Expect(markdown[sentence.Start:sentence.End]).To(Equal(sentence.Markdown))
+			}
+			Expect(texts[0]).To(Equal("The tldr package is a summarizer for Go programs."))
+			Expect(summary.Sentences[0].Markdown).To(Equal("The tldr package is a **summarizer** for [Go](https://go.dev) programs."))
+			Expect(summary.Sentences[1].Markdown).To(Equal("The summarizer ranks sentences of the text with `LexRank`."))
+			Expect(summary.Sentences[0].Headings).To(Equal([]string{"The tldr package"}))
+			Expect(summary.Sentences[2].Headings).To(Equal([]string{"The tldr package", "Installation"}))
+		})
+
+		It("Should rank sentences sharing words with their headings higher", func() {
+			doc := "# Summaries\n\nThe tool keeps the important sentences of the text. The text is long. The sentences are ranked.\n\n## Caching\n\nResults are kept in memory. The summaries are cached in memory.\n"
+			inOrder := func(edges []*Edge) []int {
+				return []int{0, 1, 2, 3, 4}
+			}
+
+			bag := New()
+			bag.Language = "english"
+			bag.Algorithm = "custom"
+			bag.SetCustomAlgorithm(inOrder)
+			summary, err := bag.SummarizeMarkdown(doc, 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences[0].Text).To(Equal("The tool keeps the important sentences of the text."))
+
+			bag.HeadingWeight = 4
+			summary, err = bag.SummarizeMarkdown(doc, 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences[0].Text).To(Equal("The summaries are cached in memory."))
+			Expect(strings.Join(summary.Sentences[0].Headings, " > ")).To(Equal("Summaries > Caching"))
+		})
+
+		It("Should return no sentences for a document without prose", func() {
+			summary, err := New().SummarizeMarkdown("# Title\n\n```\ncode\n```\n", 3)
+			Expect(err).To(BeNil())
+			Expect(summary.Title).To(Equal("Title"))
+			Expect(summary.Sentences).To(BeEmpty())
+		})
+	})
+})
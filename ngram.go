@@ -0,0 +1,46 @@
+package tldr
+
+import (
+	"strings"
+)
+
+// charNGramPrefix marks character n-gram features, so they never collide with words
+const charNGramPrefix = "#"
+
+// createFeatures turns the words of every sentence into the features of its vector,
+// adding word and character n-grams if they are enabled
+func (bag *Bag) createFeatures() {
+	if bag.WordNGrams <= 1 && bag.CharNGrams <= 0 {
+		bag.featuresPerSentence = bag.BagOfWordsPerSentence
+		return
+	}
+
+	bag.featuresPerSentence = make([][]string, 0, len(bag.BagOfWordsPerSentence))
+	for _, words := range bag.BagOfWordsPerSentence {
+		bag.featuresPerSentence = append(bag.featuresPerSentence, NGrams(words, bag.WordNGrams, bag.CharNGrams))
+	}
+}
+
+// NGrams returns the words along with their word n-grams up to wordN words long, joined by a space,
+// and their character n-grams of charN runes, prefixed by # and padded with a space at word boundaries
+func NGrams(words []string, wordN, charN int) []string {
+	features := make([]string, 0, len(words)*(wordN+1))
+	features = append(features, words...)
+
+	for n := 2; n <= wordN; n++ {
+		for i := 0; i+n <= len(words); i++ {
+			features = append(features, strings.Join(words[i:i+n], " "))
+		}
+	}
+
+	if charN > 0 {
+		for _, word := range words {
+			runes := []rune(" " + word + " ")
+			for i := 0; i+charN <= len(runes); i++ {
+				features = append(features, charNGramPrefix+string(runes[i:i+charN]))
+			}
+		}
+	}
+
+	return features
+}
@@ -0,0 +1,60 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("N-gram features", func() {
+	Describe("NGrams()", func() {
+		It("Should return only the words by default", func() {
+			Expect(NGrams([]string{"new", "york"}, 1, 0)).To(Equal([]string{"new", "york"}))
+		})
+
+		It("Should add word n-grams", func() {
+			Expect(NGrams([]string{"in", "new", "york"}, 3, 0)).To(Equal([]string{
+				"in", "new", "york", "in new", "new york", "in new york",
+			}))
+		})
+
+		It("Should add character n-grams", func() {
+			Expect(NGrams([]string{"cat"}, 1, 3)).To(Equal([]string{
+				"cat", "# ca", "#cat", "#at ",
+			}))
+		})
+	})
+
+	Describe("Bag with n-grams", func() {
+		var bag *Bag
+
+		BeforeEach(func() {
+			bag = New()
+		})
+
+		It("Should tell word orders apart with word n-grams", func() {
+			bag.WordNGrams = 2
+			_, err := bag.Summarize("I live in New York. York new is not a place. New York is big.", 1)
+			Expect(err).To(BeNil())
+			Expect(bag.Dict).To(HaveKey("new york"))
+			Expect(bag.Dict).To(HaveKey("york new"))
+			Expect(bag.BagOfWordsPerSentence[0]).NotTo(ContainElement("new york"))
+		})
+
+		It("Should add character n-grams to the dictionary", func() {
+			bag.CharNGrams = 4
+			_, err := bag.Summarize("Cats sleep. Cat sleeps. Dogs bark.", 1)
+			Expect(err).To(BeNil())
+			Expect(bag.Dict).To(HaveKey("#slee"))
+		})
+
+		It("Should still summarize", func() {
+			bag.WordNGrams = 3
+			bag.CharNGrams = 3
+			sums, err := bag.Summarize(text, 3)
+			Expect(err).To(BeNil())
+			Expect(sums).To(HaveLen(3))
+		})
+	})
+})
@@ -0,0 +1,208 @@
+package tldr
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Preprocessor cleans up the text before it is split into sentences.
+// Along with the new text it returns the offset in text of every byte of the new text,
+// plus one last offset for the end of it.
+type Preprocessor func(text string) (string, []int)
+
+// DefaultPreprocessors are the preprocessors suitable for text scraped from the web
+var DefaultPreprocessors = []Preprocessor{
+	NormalizeUnicode,
+	RemoveInvisibles,
+	CanonicalizeWhitespace,
+	CanonicalizeQuotes,
+	Dehyphenate,
+}
+
+// PreprocessedText is a text cleaned up by preprocessors,
+// which remembers where every byte of it came from in the original text
+type PreprocessedText struct {
+	Original string
+	Text     string
+	offsets  []int // offset in Original of every byte of Text and its end, nil if they are the same
+}
+
+// Preprocess runs the preprocessors over text, one after another
+func Preprocess(text string, preprocessors ...Preprocessor) *PreprocessedText {
+	pre := &PreprocessedText{
+		Original: text,
+		Text:     text,
+	}
+	for _, preprocessor := range preprocessors {
+		processed, offsets := preprocessor(pre.Text)
+		if pre.offsets != nil {
+			for i, offset := range offsets {
+				offsets[i] = pre.offsets[offset]
+			}
+		}
+		pre.Text = processed
+		pre.offsets = offsets
+	}
+	return pre
+}
+
+// OriginalOffset maps a byte offset in Text to the byte offset in Original it came from
+func (pre *PreprocessedText) OriginalOffset(offset int) int {
+	if pre.offsets == nil {
+		return offset
+	}
+	if offset < 0 {
+		return 0
+	}
+	if offset >= len(pre.offsets) {
+		return len(pre.Original)
+	}
+	return pre.offsets[offset]
+}
+
+// SetPreprocessors sets the preprocessors run over the text before it is split into sentences,
+// example: bag.SetPreprocessors(tldr.DefaultPreprocessors...)
+func (bag *Bag) SetPreprocessors(preprocessors ...Preprocessor) {
+	bag.preprocessors = preprocessors
+}
+
+// offsetBuilder builds a preprocessed text along with the offsets of its bytes
+type offsetBuilder struct {
+	buf     strings.Builder
+	offsets []int
+}
+
+func newOffsetBuilder(size int) *offsetBuilder {
+	b := &offsetBuilder{
+		offsets: make([]int, 0, size+1),
+	}
+	b.buf.Grow(size)
+	return b
+}
+
+// keep writes s unchanged, it was found at from in the input
+func (b *offsetBuilder) keep(s string, from int) {
+	b.buf.WriteString(s)
+	for i := range len(s) {
+		b.offsets = append(b.offsets, from+i)
+	}
+}
+
+// replace writes s in place of the input found at from
+func (b *offsetBuilder) replace(s string, from int) {
+	b.buf.WriteString(s)
+	for range len(s) {
+		b.offsets = append(b.offsets, from)
+	}
+}
+
+func (b *offsetBuilder) done(end int) (string, []int) {
+	return b.buf.String(), append(b.offsets, end)
+}
+
+// mapRunes builds a preprocessor replacing every rune by the string f returns for it
+func mapRunes(text string, f func(r rune) (string, bool)) (string, []int) {
+	b := newOffsetBuilder(len(text))
+	for i, r := range text {
+		if replacement, replaced := f(r); replaced {
+			b.replace(replacement, i)
+		} else {
+			b.keep(text[i:i+utf8.RuneLen(r)], i)
+		}
+	}
+	return b.done(len(text))
+}
+
+// NormalizeUnicode composes decomposed characters (NFC), so an e followed by a combining acute accent becomes é
+func NormalizeUnicode(text string) (string, []int) {
+	b := newOffsetBuilder(len(text))
+	var it norm.Iter
+	it.InitString(norm.NFC, text)
+	for !it.Done() {
+		from := it.Pos()
+		segment := string(it.Next())
+		if segment == text[from:it.Pos()] {
+			b.keep(segment, from)
+		} else {
+			b.replace(segment, from)
+		}
+	}
+	return b.done(len(text))
+}
+
+// RemoveInvisibles removes zero width characters, soft hyphens and byte order marks
+func RemoveInvisibles(text string) (string, []int) {
+	return mapRunes(text, func(r rune) (string, bool) {
+		switch r {
+		case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff', '\u00ad':
+			return "", true
+		}
+		return "", false
+	})
+}
+
+// CanonicalizeWhitespace turns every kind of space into a plain space and collapses runs of them,
+// line breaks are kept but carriage returns are dropped
+func CanonicalizeWhitespace(text string) (string, []int) {
+	b := newOffsetBuilder(len(text))
+	space := false
+	for i, r := range text {
+		switch {
+		case r == '\n':
+			b.keep("\n", i)
+			space = false
+		case r == '\r':
+		case unicode.IsSpace(r) || unicode.Is(unicode.Zs, r):
+			if !space {
+				b.replace(" ", i)
+			}
+			space = true
+		default:
+			b.keep(text[i:i+utf8.RuneLen(r)], i)
+			space = false
+		}
+	}
+	return b.done(len(text))
+}
+
+// CanonicalizeQuotes turns typographic quotes into plain ASCII quotes
+func CanonicalizeQuotes(text string) (string, []int) {
+	return mapRunes(text, func(r rune) (string, bool) {
+		switch r {
+		case '‘', '’', '‚', '‛', '′':
+			return "'", true
+		case '“', '”', '„', '‟', '″', '«', '»':
+			return "\"", true
+		}
+		return "", false
+	})
+}
+
+// Dehyphenate joins words broken by a hyphen at the end of a line, so "summar-\nization" becomes "summarization"
+func Dehyphenate(text string) (string, []int) {
+	b := newOffsetBuilder(len(text))
+	var prev rune
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r == '-' && unicode.IsLetter(prev) {
+			// skip the hyphen and the line break, only if a lowercase letter follows
+			j := i + size
+			j += len(text[j:]) - len(strings.TrimLeft(text[j:], " \t\r"))
+			if j < len(text) && text[j] == '\n' {
+				j += len(text[j:]) - len(strings.TrimLeft(text[j:], " \t\r\n"))
+				if next, _ := utf8.DecodeRuneInString(text[j:]); unicode.IsLower(next) {
+					prev = r
+					i = j
+					continue
+				}
+			}
+		}
+		b.keep(text[i:i+size], i)
+		prev = r
+		i += size
+	}
+	return b.done(len(text))
+}
@@ -0,0 +1,90 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+)
+
+var _ = Describe("Preprocessors", func() {
+	Describe("NormalizeUnicode()", func() {
+		It("Should compose decomposed accents", func() {
+			processed, offsets := NormalizeUnicode("cafe\u0301 ok")
+			Expect(processed).To(Equal("café ok"))
+			Expect(offsets).To(HaveLen(len(processed) + 1))
+			Expect(offsets[len(offsets)-1]).To(Equal(len("cafe\u0301 ok")))
+		})
+	})
+
+	Describe("RemoveInvisibles()", func() {
+		It("Should remove zero width characters and soft hyphens", func() {
+			processed, _ := RemoveInvisibles("\ufeffzero\u200bwidth sum\u00admary")
+			Expect(processed).To(Equal("zerowidth summary"))
+		})
+	})
+
+	Describe("CanonicalizeWhitespace()", func() {
+		It("Should collapse spaces but keep line breaks", func() {
+			processed, _ := CanonicalizeWhitespace("a\u00a0\u00a0b \t c\r\nd")
+			Expect(processed).To(Equal("a b c\nd"))
+		})
+	})
+
+	Describe("CanonicalizeQuotes()", func() {
+		It("Should turn smart quotes into ASCII quotes", func() {
+			processed, _ := CanonicalizeQuotes("“It’s fine,” she said.")
+			Expect(processed).To(Equal("\"It's fine,\" she said."))
+		})
+	})
+
+	Describe("Dehyphenate()", func() {
+		It("Should join words broken at line ends", func() {
+			processed, _ := Dehyphenate("automatic summar-\n  ization and well-known terms, like Star-\nWars")
+			Expect(processed).To(Equal("automatic summarization and well-known terms, like Star-\nWars"))
+		})
+	})
+
+	Describe("Preprocess()", func() {
+		It("Should map offsets back through every preprocessor", func() {
+			original := "The “summar-\nization” of cafe\u0301s."
+			pre := Preprocess(original, DefaultPreprocessors...)
+			Expect(pre.Text).To(Equal("The \"summarization\" of cafés."))
+
+			start := strings.Index(pre.Text, "cafés")
+			end := start + len("cafés")
+			Expect(original[pre.OriginalOffset(start):pre.OriginalOffset(end)]).To(Equal("cafe\u0301s"))
+			Expect(pre.OriginalOffset(strings.Index(pre.Text, "ization"))).To(Equal(strings.Index(original, "ization")))
+		})
+
+		It("Should leave the text untouched without preprocessors", func() {
+			pre := Preprocess("Some text.")
+			Expect(pre.Text).To(Equal("Some text."))
+			Expect(pre.OriginalOffset(5)).To(Equal(5))
+		})
+	})
+
+	Describe("Bag with preprocessors", func() {
+		It("Should report where the selected sentences are in the original text", func() {
+			original := "The sum\u00admary of the\u00a0news is here. The news has been summar-\nized by the tool. Nobody reads the news."
+			bag := New()
+			bag.SetPreprocessors(DefaultPreprocessors...)
+			summary, err := bag.SummarizeDetailed(original, 3)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences).To(HaveLen(3))
+			Expect(summary.Sentences[1].Text).To(Equal("The news has been summarized by the tool."))
+			Expect(original[summary.Sentences[1].Start:summary.Sentences[1].End]).To(Equal("The news has been summar-\nized by the tool."))
+			Expect(original[summary.Sentences[0].Start:summary.Sentences[0].End]).To(Equal("The sum\u00admary of the\u00a0news is here."))
+		})
+
+		It("Should report the sentence offsets without preprocessors", func() {
+			summary, err := New().SummarizeDetailed(text, 3)
+			Expect(err).To(BeNil())
+			for _, sentence := range summary.Sentences {
+				Expect(text[sentence.Start:sentence.End]).To(Equal(sentence.Text))
+			}
+		})
+	})
+})
@@ -0,0 +1,238 @@
+package tldr
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	readerChunkSize  = 64 * 1024 // bytes read from the reader at once
+	maxPendingLength = 1 << 20   // text without a sentence end kept before it is taken as one sentence
+	readerSentences  = 1000      // most sentences ranked at once by SummarizeReader
+	readerKept       = 500       // sentences kept out of the readerSentences ranked, from the highest ranked
+)
+
+// SummarizeReader works like Summarize for a text read from r.
+// The text is read in chunks and split into sentences as it comes, and the dictionary grows with every sentence.
+// Sentences are kept as sparse vectors, and at most 1000 of them at once: when that many are read,
+// they are ranked and only the top 500 are kept to be ranked again with the next ones. Memory is so bounded
+// by the size of the vocabulary and a hash of every sentence, used to skip exact duplicates,
+// but the summary of a text of more than 1000 sentences may differ from the one of Summarize.
+// OriginalSentences are then the sentences ranked in the end, and Ratio is a share of them.
+// Near duplicates are not looked for.
+func (bag *Bag) SummarizeReader(r io.Reader, num int) ([]string, error) {
+	bag.source = nil
+	bag.OriginalSentences = nil
+	bag.BagOfWordsPerSentence = nil
+	bag.featuresPerSentence = nil
+	bag.Nodes = nil
+
+	createDict := len(bag.Dict) < 1 || bag.dictCreated
+	if createDict {
+		bag.Dict = make(map[string]int)
+		bag.dictCreated = true
+	}
+
+	seen := make(map[uint64]bool)
+	err := bag.readSentences(r, func(sentence string) {
+		words := bag.normalizeWords(bag.wordTokenizer(sentence))
+		hash := fnv.New64a()
+		hash.Write([]byte(strings.Join(words, " ")))
+		key := hash.Sum64()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		features := words
+		if bag.WordNGrams > 1 || bag.CharNGrams > 0 {
+			features = NGrams(words, bag.WordNGrams, bag.CharNGrams)
+		}
+		positions := make([]int, 0, len(features))
+		for _, feature := range features {
+			if feature == "" {
+				continue
+			}
+			position, exists := bag.Dict[feature]
+			if !exists && createDict {
+				position = len(bag.Dict) + 1
+				bag.Dict[feature] = position
+			}
+			if position > 0 {
+				positions = append(positions, position)
+			}
+		}
+
+		bag.Nodes = append(bag.Nodes, &Node{len(bag.OriginalSentences), nil, uniqInts(positions)})
+		bag.OriginalSentences = append(bag.OriginalSentences, sentence)
+		if len(bag.Nodes) == readerSentences {
+			bag.keepTopSentences()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(bag.OriginalSentences) == 0 {
+		return nil, nil
+	}
+
+	bag.vectorLength = len(bag.Dict)
+	idx, err := bag.rankNodes(num, nil)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+
+	return bag.concatResult(idx), nil
+}
+
+// keepTopSentences ranks the sentences read so far and keeps the readerKept highest ranked, in the order they were read.
+// If fewer are ranked, the first ones not ranked are kept too.
+func (bag *Bag) keepTopSentences() {
+	bag.vectorLength = len(bag.Dict)
+	bag.scoreNodes()
+
+	kept := make([]bool, len(bag.Nodes))
+	count := 0
+	for _, i := range append(bag.Ranks, seq(len(bag.Nodes))...) {
+		if count == readerKept {
+			break
+		}
+		if i >= 0 && i < len(kept) && !kept[i] {
+			kept[i] = true
+			count++
+		}
+	}
+
+	nodes := make([]*Node, 0, readerSentences)
+	sentences := make([]string, 0, readerSentences)
+	for i, node := range bag.Nodes {
+		if kept[i] {
+			node.sentenceIndex = len(nodes)
+			nodes = append(nodes, node)
+			sentences = append(sentences, bag.OriginalSentences[i])
+		}
+	}
+	bag.Nodes, bag.OriginalSentences = nodes, sentences
+	bag.Edges, bag.Ranks, bag.scores = nil, nil, nil
+}
+
+// seq returns the ints from 0 to n excluded
+func seq(n int) []int {
+	ints := make([]int, n)
+	for i := range ints {
+		ints[i] = i
+	}
+	return ints
+}
+
+// readSentences reads r chunk by chunk and calls f with every sentence once it is known to be complete.
+// The language is resolved from the first chunk.
+func (bag *Bag) readSentences(r io.Reader, f func(sentence string)) error {
+	chunk := make([]byte, readerChunkSize)
+	var pending []byte
+	resolved := false
+	for {
+		n, err := io.ReadFull(r, chunk)
+		pending = append(pending, chunk[:n]...)
+		eof := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !eof {
+			return fmt.Errorf("tldr: reading text: %w", err)
+		}
+
+		// leave a rune cut by the end of the chunk for the next read
+		complete := len(pending)
+		for i := len(pending) - 1; !eof && i >= 0 && i >= len(pending)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(pending[i]) {
+				if !utf8.FullRune(pending[i:]) {
+					complete = i
+				}
+				break
+			}
+		}
+		text := Preprocess(string(pending[:complete]), bag.preprocessors...).Text
+		if !resolved {
+			bag.resolveLanguage(text)
+			resolved = true
+		}
+
+		if eof {
+			for _, sentence := range bag.splitBlock(text) {
+				f(sentence)
+			}
+			return nil
+		}
+
+		// the last sentence may go on in the next chunk, keep it
+		tail := text
+		if sentences := bag.tokenizeSentences(text); len(sentences) > 1 {
+			from := 0
+			for _, sentence := range sentences[:len(sentences)-1] {
+				f(sentence)
+				if at := strings.Index(text[from:], sentence); at >= 0 {
+					from += at + len(sentence)
+				}
+			}
+			tail = text[from:]
+		} else if len(text) > maxPendingLength {
+			for _, sentence := range bag.splitBlock(text) {
+				f(sentence)
+			}
+			tail = ""
+		}
+		pending = append([]byte(tail), pending[complete:]...)
+	}
+}
+
+// sparseWeight weighs the similarity of two sentences from the sorted dict positions of their words
+func (bag *Bag) sparseWeight(src, dst []int) float64 {
+	if bag.Weighing == "custom" {
+		return bag.customWeighing(denseVector(src, bag.vectorLength), denseVector(dst, bag.vectorLength))
+	}
+
+	common := 0
+	for i, j := 0, 0; i < len(src) && j < len(dst); {
+		switch {
+		case src[i] < dst[j]:
+			i++
+		case src[i] > dst[j]:
+			j++
+		default:
+			common++
+			i++
+			j++
+		}
+	}
+	different := len(src) + len(dst) - 2*common
+
+	if bag.Weighing == "jaccard" {
+		// same as comparing the full vectors, where absent words are equal too
+		equal := float64(bag.vectorLength - different)
+		return 1.0 - equal/(float64(bag.vectorLength*2)-equal)
+	}
+	return float64(different)
+}
+
+// denseVector turns the dict positions of the words of a sentence into its vector
+func denseVector(positions []int, length int) []int {
+	vector := make([]int, length)
+	for _, position := range positions {
+		vector[position-1] = 1
+	}
+	return vector
+}
+
+// uniqInts sorts the ints and removes the duplicates
+func uniqInts(ints []int) []int {
+	sort.Ints(ints)
+	uniq := ints[:0]
+	for i, n := range ints {
+		if i == 0 || n != ints[i-1] {
+			uniq = append(uniq, n)
+		}
+	}
+	return uniq
+}
@@ -0,0 +1,85 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"errors"
+	"fmt"
+	"strings"
+	"testing/iotest"
+	"unicode/utf8"
+)
+
+var _ = Describe("SummarizeReader()", func() {
+	It("Should summarize like Summarize", func() {
+		for _, algorithm := range []string{"pagerank", "centrality"} {
+			for _, weighing := range []string{"hamming", "jaccard"} {
+				bag := New()
+				bag.Algorithm, bag.Weighing = algorithm, weighing
+				expected, err := bag.Summarize(text, 3)
+				Expect(err).To(BeNil())
+
+				bag = New()
+				bag.Algorithm, bag.Weighing = algorithm, weighing
+				summary, err := bag.SummarizeReader(iotest.OneByteReader(strings.NewReader(text)), 3)
+				Expect(err).To(BeNil())
+				Expect(summary).To(Equal(expected))
+			}
+		}
+	})
+
+	It("Should skip repeated sentences", func() {
+		expected, err := New().Summarize(text, 3)
+		Expect(err).To(BeNil())
+
+		bag := New()
+		summary, err := bag.SummarizeReader(strings.NewReader(strings.Repeat(text+"\n", 20)), 3)
+		Expect(err).To(BeNil())
+		Expect(summary).To(Equal(expected))
+	})
+
+	It("Should split sentences across chunks without breaking characters", func() {
+		var b strings.Builder
+		for i := 0; i < 200; i++ {
+			fmt.Fprintf(&b, "Sentence %d is about the naïve café %d", i, i%7)
+			b.WriteString(strings.Repeat(" and the crème brûlée", 15))
+			b.WriteString(". ")
+		}
+		Expect(b.Len()).To(BeNumerically(">", 64*1024))
+
+		bag := New()
+		_, err := bag.SummarizeReader(strings.NewReader(b.String()), 3)
+		Expect(err).To(BeNil())
+		Expect(bag.OriginalSentences).To(HaveLen(200))
+		for i, sentence := range bag.OriginalSentences {
+			Expect(utf8.ValidString(sentence)).To(BeTrue())
+			Expect(sentence).To(HavePrefix(fmt.Sprintf("Sentence %d ", i)))
+			Expect(sentence).To(HaveSuffix("brûlée."))
+		}
+	})
+
+	It("Should rank a bounded number of sentences at once", func() {
+		var b strings.Builder
+		for i := 0; i < 2200; i++ {
+			fmt.Fprintf(&b, "Sentence %d talks about topic %d and item %d. ", i, i%37, i%101)
+		}
+
+		bag := New()
+		summary, err := bag.SummarizeReader(strings.NewReader(b.String()), 3)
+		Expect(err).To(BeNil())
+		Expect(summary).To(HaveLen(3))
+		Expect(len(bag.OriginalSentences)).To(BeNumerically("<", 1000))
+		Expect(len(bag.Edges)).To(BeNumerically("<", 1000*999))
+		for _, sentence := range summary {
+			Expect(b.String()).To(ContainSubstring(sentence))
+		}
+	})
+
+	It("Should return the error of the reader", func() {
+		_, err := New().SummarizeReader(iotest.ErrReader(errors.New("broken")), 3)
+		Expect(err).To(MatchError(ContainSubstring("broken")))
+	})
+})
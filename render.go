@@ -0,0 +1,114 @@
+package tldr
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// paragraphBreak matches the blank lines between the paragraphs of a text
+var paragraphBreak = regexp.MustCompile(`\n[ \t\r\f\v]*\n\s*`)
+
+// Paragraph renders the summary as a single paragraph, its sentences on one line
+func (s *Summary) Paragraph() string {
+	texts := make([]string, 0, len(s.Sentences))
+	for _, sentence := range s.Sentences {
+		if text := OneLine(sentence.Text); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, " ")
+}
+
+// Bullets renders the summary as a list, a sentence per line starting with a bullet
+func (s *Summary) Bullets() string {
+	var b strings.Builder
+	for _, sentence := range s.Sentences {
+		b.WriteString("• ")
+		b.WriteString(OneLine(sentence.Text))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Markdown renders the summary as a Markdown list, every sentence linking to its anchor in the document at url,
+// as rendered by HTML. With an empty url, the links are to the anchors of the same page.
+// Sentences not located in the text have no link.
+func (s *Summary) Markdown(url string) string {
+	var b strings.Builder
+	for _, sentence := range s.Sentences {
+		b.WriteString("- ")
+		b.WriteString(EscapeMarkdown(OneLine(sentence.Text)))
+		if sentence.Start >= 0 {
+			fmt.Fprintf(&b, " [↗](%s#%s)", url, sentenceAnchor(sentence))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// HTML renders text, the text the summary was made from, as HTML paragraphs, split on blank lines,
+// with the sentences of the summary wrapped in <span class="tldr-highlight"> elements.
+// The first span of every sentence has its anchor, "tldr-" followed by its index, as id, and its score as data-score.
+func (s *Summary) HTML(text string) string {
+	var b strings.Builder
+	b.WriteString("<p>")
+	at := 0
+	for _, sentence := range s.Sentences {
+		if sentence.Start < at || sentence.End > len(text) || sentence.Start >= sentence.End {
+			continue
+		}
+		writeParagraphs(&b, text[at:sentence.Start], "")
+		open := fmt.Sprintf(`<span class="tldr-highlight" id="%s" data-score="%s">`, sentenceAnchor(sentence), strconv.FormatFloat(sentence.Score, 'g', 4, 64))
+		b.WriteString(open)
+		writeParagraphs(&b, text[sentence.Start:sentence.End], `<span class="tldr-highlight">`)
+		b.WriteString("</span>")
+		at = sentence.End
+	}
+	writeParagraphs(&b, text[at:], "")
+	b.WriteString("</p>")
+	return b.String()
+}
+
+// writeParagraphs writes text escaped, closing and opening a paragraph at every blank line.
+// span is the opening tag of the span text is in, closed and opened again around the breaks, if any.
+func writeParagraphs(b *strings.Builder, text, span string) {
+	for i, paragraph := range paragraphBreak.Split(text, -1) {
+		if i > 0 {
+			if span != "" {
+				b.WriteString("</span>")
+			}
+			b.WriteString("</p>\n<p>")
+			b.WriteString(span)
+		}
+		b.WriteString(html.EscapeString(paragraph))
+	}
+}
+
+// sentenceAnchor is the id of a sentence in the HTML rendering
+func sentenceAnchor(sentence *Sentence) string {
+	return "tldr-" + strconv.Itoa(sentence.Index)
+}
+
+// markdownEscaper escapes the characters of Markdown markup anywhere in a line of text
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"!", `\!`, "|", `\|`, "<", `\<`, "#", `\#`)
+
+// markdownLineStart matches the start of a line making it a quote or a list item, once escaped by markdownEscaper
+var markdownLineStart = regexp.MustCompile(`^(?:[>+\-]|\d+\.)`)
+
+// EscapeMarkdown escapes a line of text so Markdown renders it as it is, in a list item or a paragraph
+func EscapeMarkdown(line string) string {
+	line = markdownEscaper.Replace(line)
+	if block := markdownLineStart.FindString(line); block != "" {
+		line = block[:len(block)-1] + `\` + line[len(block)-1:]
+	}
+	return line
+}
+
+// OneLine puts text on one line, with its runs of spaces and line breaks collapsed into a single space
+func OneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
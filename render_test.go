@@ -0,0 +1,74 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"html"
+	"regexp"
+	"strings"
+)
+
+var _ = Describe("Render", func() {
+	source := "First one.  Sentence <two>\nhere.\n\nSecond paragraph * starts. Last one."
+	summary := &Summary{Sentences: []*Sentence{
+		{Index: 1, Text: "Sentence <two>\nhere.", Start: 12, End: 32, Score: 1},
+		{Index: 2, Text: "Second paragraph * starts.", Start: 34, End: 60, Score: 0.25},
+	}}
+
+	It("Should render a paragraph", func() {
+		Expect(summary.Paragraph()).To(Equal("Sentence <two> here. Second paragraph * starts."))
+		Expect((&Summary{}).Paragraph()).To(Equal(""))
+	})
+
+	It("Should render bullets", func() {
+		Expect(summary.Bullets()).To(Equal("• Sentence <two> here.\n• Second paragraph * starts.\n"))
+	})
+
+	It("Should render Markdown linking to the sentences", func() {
+		Expect(summary.Markdown("doc.html")).To(Equal("- Sentence \\<two> here. [↗](doc.html#tldr-1)\n- Second paragraph \\* starts. [↗](doc.html#tldr-2)\n"))
+		unknown := &Summary{Sentences: []*Sentence{{Index: 0, Text: "Nowhere.", Start: -1, End: -1}}}
+		Expect(unknown.Markdown("")).To(Equal("- Nowhere.\n"))
+	})
+
+	It("Should escape the Markdown markup of the sentences", func() {
+		Expect(EscapeMarkdown("See [the docs](http://x.y) now! | a")).To(Equal(`See \[the docs\]\(http://x.y\) now\! \| a`))
+		Expect(EscapeMarkdown("> quoted")).To(Equal(`\> quoted`))
+		Expect(EscapeMarkdown("+ plus")).To(Equal(`\+ plus`))
+		Expect(EscapeMarkdown("- minus")).To(Equal(`\- minus`))
+		Expect(EscapeMarkdown("# title")).To(Equal(`\# title`))
+		Expect(EscapeMarkdown("1984. A year")).To(Equal(`1984\. A year`))
+		Expect(EscapeMarkdown("a - b > c + 1. d")).To(Equal("a - b > c + 1. d"))
+
+		tricky := &Summary{Sentences: []*Sentence{{Index: 3, Text: "- Buy ![it](x.png)\n> now", Start: 0, End: 10}}}
+		Expect(tricky.Markdown("")).To(Equal(`- \- Buy \!\[it\]\(x.png\) > now [↗](#tldr-3)` + "\n"))
+	})
+
+	It("Should put the sentences on one line", func() {
+		Expect(OneLine(" Split\n  across\tlines ")).To(Equal("Split across lines"))
+	})
+
+	It("Should highlight the sentences in the HTML of the text", func() {
+		Expect(summary.HTML(source)).To(Equal(`<p>First one.  <span class="tldr-highlight" id="tldr-1" data-score="1">Sentence &lt;two&gt;` + "\n" +
+			`here.</span></p>` + "\n" +
+			`<p><span class="tldr-highlight" id="tldr-2" data-score="0.25">Second paragraph * starts.</span> Last one.</p>`))
+	})
+
+	It("Should keep a highlighted sentence spanning paragraphs in its spans", func() {
+		spanning := &Summary{Sentences: []*Sentence{{Index: 0, Text: "a\n\nb", Start: 0, End: 4, Score: 0.5}}}
+		Expect(spanning.HTML("a\n\nb c")).To(Equal(`<p><span class="tldr-highlight" id="tldr-0" data-score="0.5">a</span></p>` + "\n" +
+			`<p><span class="tldr-highlight">b</span> c</p>`))
+	})
+
+	It("Should highlight the sentences of a summary in their text", func() {
+		summary, err := New().SummarizeDetailed(text, 3)
+		Expect(err).To(BeNil())
+		rendered := summary.HTML(text)
+		Expect(strings.Count(rendered, `<span class="tldr-highlight" id="tldr-`)).To(Equal(3))
+
+		stripped := html.UnescapeString(regexp.MustCompile(`<[^>]*>`).ReplaceAllString(rendered, ""))
+		Expect(strings.Fields(stripped)).To(Equal(strings.Fields(text)))
+	})
+})
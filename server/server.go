@@ -0,0 +1,393 @@
+// Package server exposes tldr over HTTP.
+//
+// POST /summarize takes a JSON request like {"text": "...", "sentences": 3} and answers
+// with the detailed summary, {"language": "...", "sentences": [{"index": 0, "text": "...", "start": 0, "end": 42}]}.
+// POST /keywords takes a JSON request like {"text": "...", "keywords": 10} and answers
+// with the keywords, {"language": "...", "keywords": [{"text": "...", "score": 1}]}.
+// GET /healthz answers as long as the server runs, and GET /readyz until it starts shutting down,
+// which it does after the drain delay.
+//
+// A request taking longer than the timeout is answered at once, but the bag cannot be stopped, so its work goes on
+// in the background and keeps its slot among the texts summarized at once until it ends. Requests coming when every
+// slot is taken are answered with 503 Service Unavailable.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/didasy/tldr"
+)
+
+// The default values of the configuration
+const (
+	DEFAULT_ADDR             = ":8080"
+	DEFAULT_MAX_BODY_BYTES   = 64 << 10 // the ranking costs grow with the square of the sentences
+	DEFAULT_TIMEOUT          = 10 * time.Second
+	DEFAULT_SHUTDOWN_TIMEOUT = 15 * time.Second
+	DEFAULT_SENTENCES        = 3
+	DEFAULT_KEYWORDS         = 10
+)
+
+// Config is the configuration of a server, zero values are replaced by the defaults
+type Config struct {
+	Addr            string
+	MaxBodyBytes    int64            // largest request body accepted
+	Timeout         time.Duration    // longest time spent summarizing a request
+	MaxConcurrent   int              // largest number of texts summarized at once, timed out ones included, the number of CPUs if 0
+	ShutdownTimeout time.Duration    // longest time waited for the requests in progress when shutting down
+	DrainDelay      time.Duration    // time still serving once not ready, for the load balancers to notice, 0 for none
+	Sentences       int              // number of sentences of a summary when the request has none
+	Ratio           float64          // share of the sentences kept when the request has no number of sentences, overrides Sentences, 0 for none
+	Keywords        int              // number of keywords when the request has none
+	NewBag          func() *tldr.Bag // creates the bag summarizing a request, with the default settings, tldr.New if nil
+}
+
+// Request is the body of POST /summarize and POST /keywords. Settings left out keep the defaults of the server.
+type Request struct {
+	Text             string   `json:"text"`
+	Preset           string   `json:"preset,omitempty"` // applied before the other settings of the request
+	Sentences        int      `json:"sentences,omitempty"`
+	Ratio            float64  `json:"ratio,omitempty"`    // share of the sentences kept, used when Sentences is 0
+	Keywords         int      `json:"keywords,omitempty"` // number of keywords
+	Algorithm        string   `json:"algorithm,omitempty"`
+	Weighing         string   `json:"weighing,omitempty"`
+	KeywordAlgorithm string   `json:"keyword_algorithm,omitempty"`
+	Language         *string  `json:"language,omitempty"`
+	MaxCharacters    *int     `json:"max_characters,omitempty"`
+	MaxWords         *int     `json:"max_words,omitempty"`
+	MaxTokens        *int     `json:"max_tokens,omitempty"`
+	Truncation       string   `json:"truncation,omitempty"`
+	Ellipsis         *string  `json:"ellipsis,omitempty"`
+	Damping          *float64 `json:"damping,omitempty"`
+	Tolerance        *float64 `json:"tolerance,omitempty"`
+	Threshold        *float64 `json:"threshold,omitempty"`
+}
+
+// ErrBusy is returned by Summarize and ExtractKeywords when the server already summarizes as many texts as it can
+var ErrBusy = errors.New("too many requests in progress")
+
+// errorResponse is the body of the answers to failed requests
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server serves summaries over HTTP
+type Server struct {
+	config Config
+	ready  atomic.Bool
+	slots  chan struct{} // taken for as long as a text is summarized
+}
+
+// New creates a server from config
+func New(config Config) *Server {
+	if config.Addr == "" {
+		config.Addr = DEFAULT_ADDR
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DEFAULT_MAX_BODY_BYTES
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DEFAULT_TIMEOUT
+	}
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = runtime.NumCPU()
+	}
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = DEFAULT_SHUTDOWN_TIMEOUT
+	}
+	if config.Sentences <= 0 {
+		config.Sentences = DEFAULT_SENTENCES
+	}
+	if config.Keywords <= 0 {
+		config.Keywords = DEFAULT_KEYWORDS
+	}
+	if config.NewBag == nil {
+		config.NewBag = tldr.New
+	}
+	s := &Server{config: config, slots: make(chan struct{}, config.MaxConcurrent)}
+	s.ready.Store(true)
+	return s
+}
+
+// Config returns the configuration of the server, with the defaults in place of its zero values
+func (s *Server) Config() Config {
+	return s.config
+}
+
+// Handler returns the handler of every endpoint of the server
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/summarize", s.handle(func(ctx context.Context, req *Request) (interface{}, error) {
+		return s.Summarize(ctx, req)
+	}))
+	mux.HandleFunc("/keywords", s.handle(func(ctx context.Context, req *Request) (interface{}, error) {
+		return s.ExtractKeywords(ctx, req)
+	}))
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.HandleFunc("/readyz", s.handleReady)
+	return mux
+}
+
+// ListenAndServe serves on the address of the configuration until ctx is done,
+// then stops being ready, goes on serving for the DrainDelay, and waits for the requests in progress before returning.
+// Connections still open after the ShutdownTimeout are closed.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve works like ListenAndServe on a listener
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	httpServer := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: s.config.Timeout,
+		ReadTimeout:       s.config.Timeout,
+		WriteTimeout:      2 * s.config.Timeout,
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.ready.Store(false)
+	time.Sleep(s.config.DrainDelay)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		httpServer.Close()
+		return err
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !s.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "shutting down"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+// handle decodes the request of a POST and answers with the result of f
+func (s *Server) handle(f func(ctx context.Context, req *Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		req := &Request{}
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body larger than %d bytes", tooLarge.Limit))
+				return
+			}
+			writeError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+			return
+		}
+
+		result, err := f(r.Context(), req)
+		var invalid *RequestError
+		switch {
+		case errors.As(err, &invalid):
+			writeError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrBusy):
+			w.Header().Set("Retry-After", "1")
+			writeError(w, http.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, context.DeadlineExceeded):
+			writeError(w, http.StatusServiceUnavailable, "the request took too long")
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, err.Error())
+		default:
+			writeJSON(w, http.StatusOK, result)
+		}
+	}
+}
+
+// RequestError is returned by Summarize for requests with invalid settings
+type RequestError struct {
+	Message string
+}
+
+func (e *RequestError) Error() string {
+	return e.Message
+}
+
+// Summarize summarizes the text of the request, giving up after the timeout of the server or when ctx is done.
+// It returns a *RequestError for invalid requests, ErrBusy when every slot is taken, and the error of ctx when it gives up,
+// though the summarization then goes on in the background.
+// The sentences of the summary are never nil, so they are encoded as an empty list.
+func (s *Server) Summarize(ctx context.Context, req *Request) (*tldr.Summary, error) {
+	bag, err := s.bag(req)
+	if err != nil {
+		return nil, &RequestError{Message: err.Error()}
+	}
+
+	var summary *tldr.Summary
+	err = s.run(ctx, func() (err error) {
+		summary, err = s.summarize(bag, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if summary.Sentences == nil {
+		summary.Sentences = []*tldr.Sentence{}
+	}
+	return summary, nil
+}
+
+// ExtractKeywords extracts the keywords of the text of the request, see Summarize
+func (s *Server) ExtractKeywords(ctx context.Context, req *Request) (*tldr.Keywords, error) {
+	if req.Keywords < 0 {
+		return nil, &RequestError{Message: "keywords must be positive"}
+	}
+	bag, err := s.bag(req)
+	if err != nil {
+		return nil, &RequestError{Message: err.Error()}
+	}
+
+	num := req.Keywords
+	if num == 0 {
+		num = s.config.Keywords
+	}
+	var keywords *tldr.Keywords
+	err = s.run(ctx, func() (err error) {
+		keywords, err = bag.ExtractKeywords(req.Text, num)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keywords, nil
+}
+
+// run runs f in a free slot, giving up after the timeout of the server or when ctx is done
+func (s *Server) run(ctx context.Context, f func() error) error {
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		return ErrBusy
+	}
+
+	// the bag cannot be stopped, so it is left to finish in the background on timeout, keeping its slot
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		defer func() { <-s.slots }()
+		done <- f()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// bag creates the bag of a request, with the default settings overridden by the ones of the request
+func (s *Server) bag(req *Request) (*tldr.Bag, error) {
+	if req.Sentences < 0 {
+		return nil, fmt.Errorf("sentences must be positive")
+	}
+	if req.Ratio < 0 || req.Ratio > 1 {
+		return nil, fmt.Errorf("ratio must be between 0 and 1")
+	}
+
+	// custom algorithms and weighings set by NewBag are kept, but requests cannot ask for them
+	if req.Algorithm == "custom" || req.Weighing == "custom" {
+		return nil, fmt.Errorf("custom settings cannot be requested")
+	}
+
+	config := &tldr.Config{
+		Preset:        req.Preset,
+		Language:      req.Language,
+		MaxCharacters: req.MaxCharacters,
+		MaxWords:      req.MaxWords,
+		MaxTokens:     req.MaxTokens,
+		Ellipsis:      req.Ellipsis,
+		Damping:       req.Damping,
+		Tolerance:     req.Tolerance,
+		Threshold:     req.Threshold,
+	}
+	if req.Algorithm != "" {
+		config.Algorithm = &req.Algorithm
+	}
+	if req.Weighing != "" {
+		config.Weighing = &req.Weighing
+	}
+	if req.Truncation != "" {
+		config.Truncation = &req.Truncation
+	}
+	if req.KeywordAlgorithm != "" {
+		config.KeywordAlgorithm = &req.KeywordAlgorithm
+	}
+	// the number of sentences of the request overrides any ratio, and the ratio of the request the default one
+	ratio := 0.0
+	if req.Sentences == 0 {
+		ratio = s.config.Ratio
+		if req.Ratio != 0 {
+			ratio = req.Ratio
+		}
+	}
+	config.Ratio = &ratio
+
+	bag := s.config.NewBag()
+	if err := bag.ApplyConfig(config); err != nil {
+		return nil, err
+	}
+	return bag, nil
+}
+
+// summarize summarizes the text of the request to its number of sentences, or else to the default one,
+// unless the bag has a ratio
+func (s *Server) summarize(bag *tldr.Bag, req *Request) (*tldr.Summary, error) {
+	num := req.Sentences
+	if num == 0 {
+		num = s.config.Sentences
+	}
+	return bag.SummarizeDetailed(req.Text, num)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, &errorResponse{Error: message})
+}
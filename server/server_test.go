@@ -0,0 +1,275 @@
+package server_test
+
+import (
+	"github.com/didasy/tldr"
+	. "github.com/didasy/tldr/server"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+)
+
+func post(handler http.Handler, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/summarize", strings.NewReader(body)))
+	return recorder
+}
+
+// expectSummary compares summaries, the scores of pagerank may differ in the last digits from run to run
+func expectSummary(summary, expected *tldr.Summary) {
+	Expect(summary.Language).To(Equal(expected.Language))
+	Expect(summary.Sentences).To(HaveLen(len(expected.Sentences)))
+	for i, sentence := range summary.Sentences {
+		Expect(sentence.Index).To(Equal(expected.Sentences[i].Index))
+		Expect(sentence.Text).To(Equal(expected.Sentences[i].Text))
+		Expect(sentence.Start).To(Equal(expected.Sentences[i].Start))
+		Expect(sentence.End).To(Equal(expected.Sentences[i].End))
+		Expect(sentence.Score).To(BeNumerically("~", expected.Sentences[i].Score, 1e-6))
+	}
+}
+
+func encode(v interface{}) string {
+	data, err := json.Marshal(v)
+	Expect(err).To(BeNil())
+	return string(data)
+}
+
+var _ = Describe("Server", func() {
+	var sample string
+
+	BeforeEach(func() {
+		data, err := os.ReadFile("../sample.txt")
+		Expect(err).To(BeNil())
+		sample = string(data)
+	})
+
+	It("Should answer with the detailed summary", func() {
+		expected, err := tldr.New().SummarizeDetailed(sample, 4)
+		Expect(err).To(BeNil())
+
+		recorder := post(New(Config{}).Handler(), encode(&Request{Text: sample, Sentences: 4}))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(HavePrefix("application/json"))
+		summary := &tldr.Summary{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), summary)).To(Succeed())
+		expectSummary(summary, expected)
+	})
+
+	It("Should use the defaults of the server and the settings of the request", func() {
+		handler := New(Config{
+			Sentences: 2,
+			NewBag: func() *tldr.Bag {
+				bag := tldr.New()
+				bag.Algorithm = "centrality"
+				return bag
+			},
+		}).Handler()
+
+		centrality := tldr.New()
+		centrality.Algorithm = "centrality"
+		expected, err := centrality.SummarizeDetailed(sample, 2)
+		Expect(err).To(BeNil())
+		summary := &tldr.Summary{}
+		Expect(json.Unmarshal(post(handler, encode(&Request{Text: sample})).Body.Bytes(), summary)).To(Succeed())
+		expectSummary(summary, expected)
+
+		maxCharacters := 50
+		recorder := post(handler, encode(&Request{Text: sample, Algorithm: "pagerank", MaxCharacters: &maxCharacters}))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		summary = &tldr.Summary{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), summary)).To(Succeed())
+		Expect(len(summary.Sentences[0].Text)).To(BeNumerically("<=", 50))
+	})
+
+	It("Should apply the preset of the request", func() {
+		recorder := post(New(Config{}).Handler(), `{"text": `+encode(sample)+`, "preset": "short-review", "sentences": 5}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		summary := &tldr.Summary{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), summary)).To(Succeed())
+		length := 0
+		for _, sentence := range summary.Sentences {
+			length += len([]rune(sentence.Text))
+		}
+		Expect(length).To(BeNumerically("<=", 280))
+		Expect(length).To(BeNumerically(">", 200))
+		// the last sentence is cut between words
+		last := summary.Sentences[len(summary.Sentences)-1].Text
+		Expect(strings.Contains(sample, last+" ")).To(BeTrue())
+
+		recorder = post(New(Config{}).Handler(), `{"text": "a", "preset": "poetry"}`)
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("Should keep a share of the sentences", func() {
+		bag := tldr.New()
+		_, err := bag.Summarize(sample, 1)
+		Expect(err).To(BeNil())
+		total := len(bag.OriginalSentences)
+
+		summary := &tldr.Summary{}
+		recorder := post(New(Config{}).Handler(), encode(&Request{Text: sample, Ratio: 0.5}))
+		Expect(json.Unmarshal(recorder.Body.Bytes(), summary)).To(Succeed())
+		Expect(summary.Sentences).To(HaveLen((total + 1) / 2))
+
+		recorder = post(New(Config{Ratio: 0.5}).Handler(), encode(&Request{Text: sample, Sentences: 2}))
+		Expect(json.Unmarshal(recorder.Body.Bytes(), summary)).To(Succeed())
+		Expect(summary.Sentences).To(HaveLen(2))
+	})
+
+	It("Should fit the summary in the words of the request", func() {
+		maxWords := 60
+		summary := &tldr.Summary{}
+		recorder := post(New(Config{}).Handler(), encode(&Request{Text: sample, Sentences: 10, MaxWords: &maxWords}))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(json.Unmarshal(recorder.Body.Bytes(), summary)).To(Succeed())
+		Expect(summary.Sentences).NotTo(BeEmpty())
+		words := 0
+		for _, sentence := range summary.Sentences {
+			words += len(strings.Fields(sentence.Text))
+		}
+		Expect(words).To(BeNumerically("<=", 60))
+
+		Expect(post(New(Config{}).Handler(), `{"text": "a", "max_tokens": -1}`).Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("Should answer an empty summary for an empty text", func() {
+		recorder := post(New(Config{}).Handler(), `{"text": ""}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(Equal(`{"sentences":[]}` + "\n"))
+	})
+
+	It("Should answer with the keywords", func() {
+		recorder := httptest.NewRecorder()
+		body := encode(&Request{Text: sample, Keywords: 4})
+		New(Config{}).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/keywords", strings.NewReader(body)))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		keywords := &tldr.Keywords{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), keywords)).To(Succeed())
+		expected, err := tldr.New().ExtractKeywords(sample, 4)
+		Expect(err).To(BeNil())
+		Expect(keywords.Keywords).To(HaveLen(4))
+		for i, keyword := range keywords.Keywords {
+			Expect(keyword.Text).To(Equal(expected.Keywords[i].Text))
+		}
+
+		keywords, err = New(Config{Keywords: 2}).ExtractKeywords(context.Background(), &Request{Text: sample})
+		Expect(err).To(BeNil())
+		Expect(keywords.Keywords).To(HaveLen(2))
+	})
+
+	It("Should reject invalid requests", func() {
+		handler := New(Config{}).Handler()
+		Expect(post(handler, `{"text": `).Code).To(Equal(http.StatusBadRequest))
+		Expect(post(handler, `{"body": "text"}`).Code).To(Equal(http.StatusBadRequest))
+		Expect(post(handler, `{"text": "a", "sentences": -1}`).Code).To(Equal(http.StatusBadRequest))
+		recorder := post(handler, `{"text": "a", "algorithm": "random"}`)
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(recorder.Body.String()).To(ContainSubstring(`unknown algorithm`))
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/summarize", nil))
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		Expect(recorder.Header().Get("Allow")).To(Equal(http.MethodPost))
+	})
+
+	It("Should reject requests larger than the limit", func() {
+		recorder := post(New(Config{MaxBodyBytes: 100}).Handler(), encode(&Request{Text: sample}))
+		Expect(recorder.Code).To(Equal(http.StatusRequestEntityTooLarge))
+	})
+
+	It("Should give up on requests taking too long", func() {
+		handler := New(Config{
+			Timeout: 10 * time.Millisecond,
+			NewBag: func() *tldr.Bag {
+				bag := tldr.New()
+				bag.Algorithm = "custom"
+				bag.SetCustomAlgorithm(func(e []*tldr.Edge) []int {
+					time.Sleep(200 * time.Millisecond)
+					return []int{0}
+				})
+				return bag
+			},
+		}).Handler()
+		recorder := post(handler, encode(&Request{Text: sample}))
+		Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("Should answer busy while every slot is taken, even by requests that timed out", func() {
+		started, release := make(chan struct{}, 1), make(chan struct{})
+		handler := New(Config{
+			Timeout:       10 * time.Millisecond,
+			MaxConcurrent: 1,
+			NewBag: func() *tldr.Bag {
+				bag := tldr.New()
+				bag.Algorithm = "custom"
+				bag.SetCustomAlgorithm(func(e []*tldr.Edge) []int {
+					select {
+					case started <- struct{}{}:
+					default:
+					}
+					<-release
+					return []int{0}
+				})
+				return bag
+			},
+		}).Handler()
+		recorder := post(handler, encode(&Request{Text: sample}))
+		Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(recorder.Body.String()).To(ContainSubstring("took too long"))
+		<-started
+
+		recorder = post(handler, encode(&Request{Text: sample}))
+		Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(recorder.Header().Get("Retry-After")).To(Equal("1"))
+		Expect(recorder.Body.String()).To(ContainSubstring(ErrBusy.Error()))
+
+		close(release)
+		Eventually(func() string {
+			return post(handler, encode(&Request{Text: sample})).Body.String()
+		}).ShouldNot(ContainSubstring(ErrBusy.Error()))
+	})
+
+	It("Should be ready until it shuts down", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		drain, timeout := 500*time.Millisecond, 2*time.Second
+		s := New(Config{DrainDelay: drain, ShutdownTimeout: timeout})
+		ctx, cancel := context.WithCancel(context.Background())
+		served := make(chan error, 1)
+		go func() {
+			served <- s.Serve(ctx, listener)
+		}()
+
+		url := "http://" + listener.Addr().String()
+		get := func(path string) int {
+			res, err := http.Get(url + path)
+			if err != nil {
+				return 0
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+			return res.StatusCode
+		}
+		Expect(get("/healthz")).To(Equal(http.StatusOK))
+		Expect(get("/readyz")).To(Equal(http.StatusOK))
+
+		// the keep-alive connections of the default client are still open
+		cancel()
+		stopping := time.Now()
+		Eventually(func() int { return get("/readyz") }).Should(Equal(http.StatusServiceUnavailable))
+		Expect(get("/healthz")).To(Equal(http.StatusOK))
+		Expect(time.Since(stopping)).To(BeNumerically("<", drain))
+		Eventually(served, drain+timeout).Should(Receive(BeNil()))
+		Expect(time.Since(stopping)).To(BeNumerically(">=", drain))
+	})
+})
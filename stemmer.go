@@ -0,0 +1,95 @@
+package tldr
+
+import (
+	"strings"
+)
+
+// Stemmers holds the built in stemmers keyed by language name.
+// All of them are pure Go ports of the Snowball algorithms and expect a lowercased word.
+var Stemmers = map[string]func(word string) string{
+	"english":    StemEnglish,
+	"german":     StemGerman,
+	"spanish":    StemSpanish,
+	"indonesian": StemIndonesian,
+}
+
+// Stemmer returns the built in stemmer for the language name, or nil if there is none
+func Stemmer(language string) func(word string) string {
+	return Stemmers[strings.ToLower(language)]
+}
+
+// SetStemmer sets the function used to reduce every tokenized word to its stem, overriding the one of the language.
+// It runs after the word tokenizer, pass nil to go back to the stemmer of the language.
+func (bag *Bag) SetStemmer(f func(word string) string) {
+	bag.stemmer = f
+}
+
+// normalizeWords runs the normalization stages over words produced by the word tokenizer
+func (bag *Bag) normalizeWords(words []string) []string {
+	stemmer := bag.currentStemmer()
+	if stemmer == nil && bag.stopWords == nil && bag.language == nil {
+		return words
+	}
+
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		if word == "" || bag.isStopWord(word) {
+			continue
+		}
+		if stemmer != nil {
+			word = stemmer(word)
+		}
+		if word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	return normalized
+}
+
+// snowball regions and suffix helpers shared by the stemmers, all working on runes
+
+func hasSuffixRunes(w []rune, suffix string) bool {
+	s := []rune(suffix)
+	if len(s) > len(w) {
+		return false
+	}
+	w = w[len(w)-len(s):]
+	for i := range s {
+		if w[i] != s[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// longestSuffix returns the longest of suffixes w ends with, or "" if none
+func longestSuffix(w []rune, suffixes ...string) string {
+	longest := ""
+	for _, suffix := range suffixes {
+		if len(suffix) > len(longest) && hasSuffixRunes(w, suffix) {
+			longest = suffix
+		}
+	}
+	return longest
+}
+
+func replaceSuffixRunes(w []rune, suffix, replacement string) []rune {
+	w = w[:len(w)-len([]rune(suffix))]
+	return append(w, []rune(replacement)...)
+}
+
+// suffixStart returns the index where suffix would start in w
+func suffixStart(w []rune, suffix string) int {
+	return len(w) - len([]rune(suffix))
+}
+
+// nextRegion returns the start of the region after the first non-vowel following a vowel,
+// searching from index from. It returns len(w) if there is no such non-vowel.
+func nextRegion(w []rune, from int, isVowel func(rune) bool) int {
+	for i := from + 1; i < len(w); i++ {
+		if !isVowel(w[i]) && isVowel(w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(w)
+}
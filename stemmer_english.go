@@ -0,0 +1,320 @@
+package tldr
+
+/*
+English stemmer, adapted to Go from the Porter2 algorithm:
+https://snowballstem.org/algorithms/english/stemmer.html
+*/
+
+var englishExceptions = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idl",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+	"sky":    "sky",
+	"news":   "news",
+	"howe":   "howe",
+	"atlas":  "atlas",
+	"cosmos": "cosmos",
+	"bias":   "bias",
+	"andes":  "andes",
+}
+
+// words left untouched after step 1a
+var englishExceptionsStep1a = map[string]bool{
+	"inning":  true,
+	"outing":  true,
+	"canning": true,
+	"herring": true,
+	"earring": true,
+	"proceed": true,
+	"exceed":  true,
+	"succeed": true,
+}
+
+func isEnglishVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+func isEnglishDouble(w []rune) bool {
+	if len(w) < 2 || w[len(w)-1] != w[len(w)-2] {
+		return false
+	}
+	switch w[len(w)-1] {
+	case 'b', 'd', 'f', 'g', 'm', 'n', 'p', 'r', 't':
+		return true
+	}
+	return false
+}
+
+func isEnglishLiEnding(r rune) bool {
+	switch r {
+	case 'c', 'd', 'e', 'g', 'h', 'k', 'm', 'n', 'r', 't':
+		return true
+	}
+	return false
+}
+
+// isEnglishShortSyllable reports whether w ends with a short syllable
+func isEnglishShortSyllable(w []rune) bool {
+	n := len(w)
+	if n == 2 {
+		return isEnglishVowel(w[0]) && !isEnglishVowel(w[1])
+	}
+	if n >= 3 {
+		last := w[n-1]
+		return !isEnglishVowel(w[n-3]) && isEnglishVowel(w[n-2]) && !isEnglishVowel(last) &&
+			last != 'w' && last != 'x' && last != 'Y'
+	}
+	return false
+}
+
+// StemEnglish reduces a lowercased english word to its stem
+func StemEnglish(word string) string {
+	if len(word) > 0 && word[0] == '\'' {
+		word = word[1:]
+	}
+	if stem, exists := englishExceptions[word]; exists {
+		return stem
+	}
+
+	w := []rune(word)
+	if len(w) <= 2 {
+		return word
+	}
+
+	// mark consonant y
+	for i, r := range w {
+		if r == 'y' && (i == 0 || isEnglishVowel(w[i-1])) {
+			w[i] = 'Y'
+		}
+	}
+
+	var r1 int
+	switch {
+	case hasPrefixRunes(w, "gener"), hasPrefixRunes(w, "arsen"):
+		r1 = 5
+	case hasPrefixRunes(w, "commun"):
+		r1 = 6
+	default:
+		r1 = nextRegion(w, 0, isEnglishVowel)
+	}
+	r2 := nextRegion(w, r1, isEnglishVowel)
+
+	w = englishStep0(w)
+	w = englishStep1a(w)
+	if englishExceptionsStep1a[string(w)] {
+		return string(w)
+	}
+	w = englishStep1b(w, r1)
+	w = englishStep1c(w)
+	w = englishStep2(w, r1)
+	w = englishStep3(w, r1, r2)
+	w = englishStep4(w, r2)
+	w = englishStep5(w, r1, r2)
+
+	for i, r := range w {
+		if r == 'Y' {
+			w[i] = 'y'
+		}
+	}
+	return string(w)
+}
+
+func hasPrefixRunes(w []rune, prefix string) bool {
+	p := []rune(prefix)
+	if len(p) > len(w) {
+		return false
+	}
+	for i := range p {
+		if w[i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func englishStep0(w []rune) []rune {
+	if suffix := longestSuffix(w, "'s'", "'s", "'"); suffix != "" {
+		return w[:suffixStart(w, suffix)]
+	}
+	return w
+}
+
+func englishStep1a(w []rune) []rune {
+	switch suffix := longestSuffix(w, "sses", "ied", "ies", "us", "ss", "s"); suffix {
+	case "sses":
+		return replaceSuffixRunes(w, suffix, "ss")
+	case "ied", "ies":
+		if len(w) > 4 {
+			return replaceSuffixRunes(w, suffix, "i")
+		}
+		return replaceSuffixRunes(w, suffix, "ie")
+	case "s":
+		// delete if the preceding word part contains a vowel not immediately before the s
+		for _, r := range w[:len(w)-2] {
+			if isEnglishVowel(r) {
+				return w[:len(w)-1]
+			}
+		}
+	}
+	return w
+}
+
+func englishStep1b(w []rune, r1 int) []rune {
+	switch suffix := longestSuffix(w, "eed", "eedly", "ed", "edly", "ing", "ingly"); suffix {
+	case "eed", "eedly":
+		if suffixStart(w, suffix) >= r1 {
+			return replaceSuffixRunes(w, suffix, "ee")
+		}
+	case "ed", "edly", "ing", "ingly":
+		stem := w[:suffixStart(w, suffix)]
+		hasVowel := false
+		for _, r := range stem {
+			if isEnglishVowel(r) {
+				hasVowel = true
+				break
+			}
+		}
+		if !hasVowel {
+			return w
+		}
+		switch {
+		case hasSuffixRunes(stem, "at"), hasSuffixRunes(stem, "bl"), hasSuffixRunes(stem, "iz"):
+			return append(stem, 'e')
+		case isEnglishDouble(stem):
+			return stem[:len(stem)-1]
+		case r1 >= len(stem) && isEnglishShortSyllable(stem):
+			// the word is short
+			return append(stem, 'e')
+		}
+		return stem
+	}
+	return w
+}
+
+func englishStep1c(w []rune) []rune {
+	n := len(w)
+	if n > 2 && (w[n-1] == 'y' || w[n-1] == 'Y') && !isEnglishVowel(w[n-2]) {
+		w[n-1] = 'i'
+	}
+	return w
+}
+
+var englishStep2Suffixes = map[string]string{
+	"tional":  "tion",
+	"enci":    "ence",
+	"anci":    "ance",
+	"abli":    "able",
+	"entli":   "ent",
+	"izer":    "ize",
+	"ization": "ize",
+	"ational": "ate",
+	"ation":   "ate",
+	"ator":    "ate",
+	"alism":   "al",
+	"aliti":   "al",
+	"alli":    "al",
+	"fulness": "ful",
+	"ousli":   "ous",
+	"ousness": "ous",
+	"iveness": "ive",
+	"iviti":   "ive",
+	"biliti":  "ble",
+	"bli":     "ble",
+	"ogi":     "og",
+	"fulli":   "ful",
+	"lessli":  "less",
+	"li":      "",
+}
+
+func englishStep2(w []rune, r1 int) []rune {
+	suffix := longestSuffixOf(w, englishStep2Suffixes)
+	if suffix == "" || suffixStart(w, suffix) < r1 {
+		return w
+	}
+	start := suffixStart(w, suffix)
+	switch suffix {
+	case "ogi":
+		if start < 1 || w[start-1] != 'l' {
+			return w
+		}
+	case "li":
+		if start < 1 || !isEnglishLiEnding(w[start-1]) {
+			return w
+		}
+	}
+	return replaceSuffixRunes(w, suffix, englishStep2Suffixes[suffix])
+}
+
+var englishStep3Suffixes = map[string]string{
+	"tional":  "tion",
+	"ational": "ate",
+	"alize":   "al",
+	"icate":   "ic",
+	"iciti":   "ic",
+	"ical":    "ic",
+	"ful":     "",
+	"ness":    "",
+	"ative":   "",
+}
+
+func englishStep3(w []rune, r1, r2 int) []rune {
+	suffix := longestSuffixOf(w, englishStep3Suffixes)
+	if suffix == "" || suffixStart(w, suffix) < r1 {
+		return w
+	}
+	if suffix == "ative" && suffixStart(w, suffix) < r2 {
+		return w
+	}
+	return replaceSuffixRunes(w, suffix, englishStep3Suffixes[suffix])
+}
+
+func englishStep4(w []rune, r2 int) []rune {
+	suffix := longestSuffix(w, "al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement",
+		"ment", "ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion")
+	if suffix == "" || suffixStart(w, suffix) < r2 {
+		return w
+	}
+	start := suffixStart(w, suffix)
+	if suffix == "ion" && (start < 1 || (w[start-1] != 's' && w[start-1] != 't')) {
+		return w
+	}
+	return w[:start]
+}
+
+func englishStep5(w []rune, r1, r2 int) []rune {
+	n := len(w)
+	switch {
+	case n > 0 && w[n-1] == 'e':
+		if n-1 >= r2 || (n-1 >= r1 && !isEnglishShortSyllable(w[:n-1])) {
+			return w[:n-1]
+		}
+	case n > 1 && w[n-1] == 'l' && w[n-2] == 'l':
+		if n-1 >= r2 {
+			return w[:n-1]
+		}
+	}
+	return w
+}
+
+// longestSuffixOf returns the longest key of suffixes w ends with, or "" if none
+func longestSuffixOf(w []rune, suffixes map[string]string) string {
+	longest := ""
+	for suffix := range suffixes {
+		if len(suffix) > len(longest) && hasSuffixRunes(w, suffix) {
+			longest = suffix
+		}
+	}
+	return longest
+}
@@ -0,0 +1,133 @@
+package tldr
+
+import (
+	"strings"
+)
+
+/*
+German stemmer, adapted to Go from the Snowball algorithm:
+https://snowballstem.org/algorithms/german/stemmer.html
+*/
+
+func isGermanVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u', 'y', 'ä', 'ö', 'ü':
+		return true
+	}
+	return false
+}
+
+func isGermanSEnding(r rune) bool {
+	switch r {
+	case 'b', 'd', 'f', 'g', 'h', 'k', 'l', 'm', 'n', 'r', 't':
+		return true
+	}
+	return false
+}
+
+func isGermanStEnding(r rune) bool {
+	return r != 'r' && isGermanSEnding(r)
+}
+
+// StemGerman reduces a lowercased german word to its stem
+func StemGerman(word string) string {
+	w := []rune(strings.Replace(word, "ß", "ss", -1))
+
+	// mark u and y between vowels as consonants
+	for i := 1; i < len(w)-1; i++ {
+		if (w[i] == 'u' || w[i] == 'y') && isGermanVowel(w[i-1]) && isGermanVowel(w[i+1]) {
+			w[i] = w[i] - 'a' + 'A'
+		}
+	}
+
+	r1 := nextRegion(w, 0, isGermanVowel)
+	r2 := nextRegion(w, r1, isGermanVowel)
+	if r1 < 3 {
+		r1 = 3
+	}
+
+	w = germanStep1(w, r1)
+	w = germanStep2(w, r1)
+	w = germanStep3(w, r1, r2)
+
+	for i, r := range w {
+		switch r {
+		case 'U', 'ü':
+			w[i] = 'u'
+		case 'Y':
+			w[i] = 'y'
+		case 'ä':
+			w[i] = 'a'
+		case 'ö':
+			w[i] = 'o'
+		}
+	}
+	return string(w)
+}
+
+func germanStep1(w []rune, r1 int) []rune {
+	suffix := longestSuffix(w, "em", "ern", "er", "e", "en", "es", "s")
+	if suffix == "" || suffixStart(w, suffix) < r1 {
+		return w
+	}
+	start := suffixStart(w, suffix)
+	switch suffix {
+	case "e", "en", "es":
+		w = w[:start]
+		if hasSuffixRunes(w, "niss") {
+			w = w[:len(w)-1]
+		}
+		return w
+	case "s":
+		if start < 1 || !isGermanSEnding(w[start-1]) {
+			return w
+		}
+	}
+	return w[:start]
+}
+
+func germanStep2(w []rune, r1 int) []rune {
+	suffix := longestSuffix(w, "en", "er", "est", "st")
+	if suffix == "" || suffixStart(w, suffix) < r1 {
+		return w
+	}
+	start := suffixStart(w, suffix)
+	if suffix == "st" && (start < 4 || !isGermanStEnding(w[start-1])) {
+		return w
+	}
+	return w[:start]
+}
+
+func germanStep3(w []rune, r1, r2 int) []rune {
+	suffix := longestSuffix(w, "end", "ung", "ig", "ik", "isch", "lich", "heit", "keit")
+	if suffix == "" || suffixStart(w, suffix) < r2 {
+		return w
+	}
+	start := suffixStart(w, suffix)
+	switch suffix {
+	case "end", "ung":
+		w = w[:start]
+		if hasSuffixRunes(w, "ig") && suffixStart(w, "ig") >= r2 && !hasSuffixRunes(w, "eig") {
+			w = w[:len(w)-2]
+		}
+		return w
+	case "ig", "ik", "isch":
+		if start > 0 && w[start-1] == 'e' {
+			return w
+		}
+		return w[:start]
+	case "lich", "heit":
+		w = w[:start]
+		if suffix := longestSuffix(w, "er", "en"); suffix != "" && suffixStart(w, suffix) >= r1 {
+			w = w[:suffixStart(w, suffix)]
+		}
+		return w
+	case "keit":
+		w = w[:start]
+		if suffix := longestSuffix(w, "lich", "ig"); suffix != "" && suffixStart(w, suffix) >= r2 {
+			w = w[:suffixStart(w, suffix)]
+		}
+		return w
+	}
+	return w
+}
@@ -0,0 +1,163 @@
+package tldr
+
+import (
+	"strings"
+)
+
+/*
+Indonesian stemmer, adapted to Go from the Snowball algorithm:
+https://snowballstem.org/algorithms/indonesian/stemmer.html
+*/
+
+// prefix types removed from the word, they decide which suffixes may follow
+const (
+	indonesianPrefixNone = iota
+	indonesianPrefixDiMengTer
+	indonesianPrefixPer
+	indonesianPrefixKePeng
+	indonesianPrefixBer
+)
+
+func isIndonesianVowel(r byte) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
+
+type indonesianStemmer struct {
+	word    string
+	measure int
+	prefix  int
+}
+
+// StemIndonesian reduces a lowercased indonesian word to its stem
+func StemIndonesian(word string) string {
+	s := &indonesianStemmer{word: word}
+	for i := 0; i < len(word); i++ {
+		if isIndonesianVowel(word[i]) {
+			s.measure++
+		}
+	}
+	if s.measure <= 2 {
+		return word
+	}
+
+	s.removeSuffix("kah", "lah", "pun")
+	if s.measure <= 2 {
+		return s.word
+	}
+	s.removeSuffix("ku", "mu", "nya")
+	if s.measure <= 2 {
+		return s.word
+	}
+
+	if s.removeFirstOrderPrefix() {
+		if s.measure > 2 {
+			s.removeDerivationalSuffix()
+		}
+		if s.measure > 2 {
+			s.removeSecondOrderPrefix()
+		}
+		return s.word
+	}
+
+	s.removeSecondOrderPrefix()
+	if s.measure > 2 {
+		s.removeDerivationalSuffix()
+	}
+	return s.word
+}
+
+func (s *indonesianStemmer) removeSuffix(suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s.word, suffix) {
+			s.word = s.word[:len(s.word)-len(suffix)]
+			s.measure--
+			return true
+		}
+	}
+	return false
+}
+
+func (s *indonesianStemmer) removeDerivationalSuffix() {
+	switch {
+	case strings.HasSuffix(s.word, "kan"):
+		if s.prefix != indonesianPrefixKePeng && s.prefix != indonesianPrefixPer {
+			s.removeSuffix("kan")
+		}
+	case strings.HasSuffix(s.word, "an"):
+		if s.prefix != indonesianPrefixDiMengTer {
+			s.removeSuffix("an")
+		}
+	case strings.HasSuffix(s.word, "i"):
+		if s.prefix <= indonesianPrefixPer && !strings.HasSuffix(s.word, "si") {
+			s.removeSuffix("i")
+		}
+	}
+}
+
+func (s *indonesianStemmer) removeFirstOrderPrefix() bool {
+	w := s.word
+	followedByVowel := func(prefix string) bool {
+		return len(w) > len(prefix) && isIndonesianVowel(w[len(prefix)])
+	}
+
+	switch {
+	case strings.HasPrefix(w, "meng"), strings.HasPrefix(w, "peng"):
+		s.setPrefix(w[:4], w[4:])
+	case strings.HasPrefix(w, "meny") && followedByVowel("meny"):
+		s.setPrefix(w[:4], "s"+w[4:])
+	case strings.HasPrefix(w, "peny") && followedByVowel("peny"):
+		s.setPrefix(w[:4], "s"+w[4:])
+	case strings.HasPrefix(w, "mem"), strings.HasPrefix(w, "pem"):
+		if followedByVowel("mem") {
+			s.setPrefix(w[:3], "p"+w[3:])
+		} else {
+			s.setPrefix(w[:3], w[3:])
+		}
+	case strings.HasPrefix(w, "men"), strings.HasPrefix(w, "pen"), strings.HasPrefix(w, "ter"):
+		s.setPrefix(w[:3], w[3:])
+	case strings.HasPrefix(w, "di"), strings.HasPrefix(w, "me"), strings.HasPrefix(w, "ke"):
+		s.setPrefix(w[:2], w[2:])
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *indonesianStemmer) setPrefix(prefix, rest string) {
+	switch prefix[0] {
+	case 'p', 'k':
+		s.prefix = indonesianPrefixKePeng
+	default:
+		s.prefix = indonesianPrefixDiMengTer
+	}
+	s.word = rest
+	s.measure--
+}
+
+func (s *indonesianStemmer) removeSecondOrderPrefix() {
+	w := s.word
+	switch {
+	case w == "belajar" || w == "pelajar":
+		s.word = "ajar"
+		s.prefix = indonesianPrefixBer
+	case strings.HasPrefix(w, "ber"):
+		s.word = w[3:]
+		s.prefix = indonesianPrefixBer
+	case len(w) > 4 && strings.HasPrefix(w, "be") && !isIndonesianVowel(w[2]) && w[2] != 'r' && w[3:5] == "er":
+		s.word = w[2:]
+		s.prefix = indonesianPrefixBer
+	case strings.HasPrefix(w, "per"):
+		s.word = w[3:]
+		s.prefix = indonesianPrefixPer
+	case strings.HasPrefix(w, "pe"):
+		s.word = w[2:]
+		s.prefix = indonesianPrefixPer
+	default:
+		return
+	}
+	s.measure--
+}
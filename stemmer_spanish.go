@@ -0,0 +1,254 @@
+package tldr
+
+/*
+Spanish stemmer, adapted to Go from the Snowball algorithm:
+https://snowballstem.org/algorithms/spanish/stemmer.html
+*/
+
+func isSpanishVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u', 'á', 'é', 'í', 'ó', 'ú', 'ü':
+		return true
+	}
+	return false
+}
+
+var spanishAccents = map[rune]rune{
+	'á': 'a',
+	'é': 'e',
+	'í': 'i',
+	'ó': 'o',
+	'ú': 'u',
+}
+
+var spanishStep1Suffixes = []string{
+	"anza", "anzas", "ico", "ica", "icos", "icas", "ismo", "ismos", "able", "ables", "ible", "ibles",
+	"ista", "istas", "oso", "osa", "osos", "osas", "amiento", "amientos", "imiento", "imientos",
+	"adora", "ador", "ación", "adoras", "adores", "aciones", "ante", "antes", "ancia", "ancias",
+	"logía", "logías", "ución", "uciones", "encia", "encias", "amente", "mente",
+	"idad", "idades", "iva", "ivo", "ivas", "ivos",
+}
+
+var spanishStep2aSuffixes = []string{
+	"ya", "ye", "yan", "yen", "yeron", "yendo", "yo", "yó", "yas", "yes", "yais", "yamos",
+}
+
+var spanishStep2bSuffixes = []string{
+	"en", "es", "éis", "emos",
+	"arían", "arías", "arán", "arás", "aríais", "aría", "aréis", "aríamos", "aremos", "ará", "aré",
+	"erían", "erías", "erán", "erás", "eríais", "ería", "eréis", "eríamos", "eremos", "erá", "eré",
+	"irían", "irías", "irán", "irás", "iríais", "iría", "iréis", "iríamos", "iremos", "irá", "iré",
+	"aba", "ada", "ida", "ía", "ara", "iera", "ad", "ed", "id", "ase", "iese", "aste", "iste", "an",
+	"aban", "ían", "aran", "ieran", "asen", "iesen", "aron", "ieron", "ado", "ido", "ando", "iendo",
+	"ió", "ar", "er", "ir", "as", "abas", "adas", "idas", "ías", "aras", "ieras", "ases", "ieses",
+	"ís", "áis", "abais", "íais", "arais", "ierais", "aseis", "ieseis", "asteis", "isteis", "ados",
+	"idos", "amos", "ábamos", "íamos", "imos", "áramos", "iéramos", "iésemos", "ásemos",
+}
+
+// StemSpanish reduces a lowercased spanish word to its stem
+func StemSpanish(word string) string {
+	w := []rune(word)
+	if len(w) < 2 {
+		return word
+	}
+
+	rv := spanishRV(w)
+	r1 := nextRegion(w, 0, isSpanishVowel)
+	r2 := nextRegion(w, r1, isSpanishVowel)
+
+	w = spanishStep0(w, rv)
+	before := len(w)
+	w = spanishStep1(w, r1, r2)
+	if len(w) == before {
+		w = spanishStep2a(w, rv)
+		if len(w) == before {
+			w = spanishStep2b(w, rv)
+		}
+	}
+	w = spanishStep3(w, rv)
+
+	for i, r := range w {
+		if plain, exists := spanishAccents[r]; exists {
+			w[i] = plain
+		}
+	}
+	return string(w)
+}
+
+func spanishRV(w []rune) int {
+	switch {
+	case len(w) < 2:
+		return len(w)
+	case !isSpanishVowel(w[1]):
+		// region after the next following vowel
+		for i := 2; i < len(w); i++ {
+			if isSpanishVowel(w[i]) {
+				return i + 1
+			}
+		}
+		return len(w)
+	case isSpanishVowel(w[0]):
+		// region after the next consonant
+		for i := 2; i < len(w); i++ {
+			if !isSpanishVowel(w[i]) {
+				return i + 1
+			}
+		}
+		return len(w)
+	}
+	if len(w) < 3 {
+		return len(w)
+	}
+	return 3
+}
+
+func spanishStep0(w []rune, rv int) []rune {
+	suffix := longestSuffix(w, "me", "se", "sela", "selo", "selas", "selos", "la", "le", "lo",
+		"las", "les", "los", "nos")
+	if suffix == "" || suffixStart(w, suffix) < rv {
+		return w
+	}
+	stem := w[:suffixStart(w, suffix)]
+	switch before := longestSuffix(stem, "iéndo", "ándo", "ár", "ér", "ír", "ando", "iendo", "ar", "er", "ir", "yendo"); before {
+	case "":
+		return w
+	case "yendo":
+		if suffixStart(stem, before) >= rv && suffixStart(stem, before) > 0 && stem[suffixStart(stem, before)-1] == 'u' {
+			return stem
+		}
+		return w
+	default:
+		if suffixStart(stem, before) < rv {
+			return w
+		}
+		for i := suffixStart(stem, before); i < len(stem); i++ {
+			if plain, exists := spanishAccents[stem[i]]; exists {
+				stem[i] = plain
+			}
+		}
+		return stem
+	}
+}
+
+func spanishStep1(w []rune, r1, r2 int) []rune {
+	suffix := longestSuffix(w, spanishStep1Suffixes...)
+	if suffix == "" {
+		return w
+	}
+	start := suffixStart(w, suffix)
+	inR2 := func(suffix string) bool {
+		return suffix != "" && suffixStart(w, suffix) >= r2
+	}
+
+	switch suffix {
+	case "adora", "ador", "ación", "adoras", "adores", "aciones", "ante", "antes", "ancia", "ancias":
+		if start < r2 {
+			return w
+		}
+		w = w[:start]
+		if inR2(longestSuffix(w, "ic")) {
+			w = w[:len(w)-2]
+		}
+		return w
+	case "logía", "logías":
+		if start < r2 {
+			return w
+		}
+		return replaceSuffixRunes(w, suffix, "log")
+	case "ución", "uciones":
+		if start < r2 {
+			return w
+		}
+		return replaceSuffixRunes(w, suffix, "u")
+	case "encia", "encias":
+		if start < r2 {
+			return w
+		}
+		return replaceSuffixRunes(w, suffix, "ente")
+	case "amente":
+		if start < r1 {
+			return w
+		}
+		w = w[:start]
+		if inR2(longestSuffix(w, "iv")) {
+			w = w[:len(w)-2]
+			if inR2(longestSuffix(w, "at")) {
+				w = w[:len(w)-2]
+			}
+		} else if before := longestSuffix(w, "os", "ic", "ad"); inR2(before) {
+			w = w[:len(w)-2]
+		}
+		return w
+	case "mente":
+		if start < r2 {
+			return w
+		}
+		w = w[:start]
+		if before := longestSuffix(w, "ante", "able", "ible"); inR2(before) {
+			w = w[:suffixStart(w, before)]
+		}
+		return w
+	case "idad", "idades":
+		if start < r2 {
+			return w
+		}
+		w = w[:start]
+		if before := longestSuffix(w, "abil", "ic", "iv"); inR2(before) {
+			w = w[:suffixStart(w, before)]
+		}
+		return w
+	case "iva", "ivo", "ivas", "ivos":
+		if start < r2 {
+			return w
+		}
+		w = w[:start]
+		if inR2(longestSuffix(w, "at")) {
+			w = w[:len(w)-2]
+		}
+		return w
+	}
+
+	if start < r2 {
+		return w
+	}
+	return w[:start]
+}
+
+func spanishStep2a(w []rune, rv int) []rune {
+	suffix := longestSuffix(w, spanishStep2aSuffixes...)
+	if suffix == "" || suffixStart(w, suffix) < rv {
+		return w
+	}
+	start := suffixStart(w, suffix)
+	if start > 0 && w[start-1] == 'u' {
+		return w[:start]
+	}
+	return w
+}
+
+func spanishStep2b(w []rune, rv int) []rune {
+	suffix := longestSuffix(w, spanishStep2bSuffixes...)
+	if suffix == "" || suffixStart(w, suffix) < rv {
+		return w
+	}
+	w = w[:suffixStart(w, suffix)]
+	switch suffix {
+	case "en", "es", "éis", "emos":
+		if hasSuffixRunes(w, "gu") {
+			w = w[:len(w)-1]
+		}
+	}
+	return w
+}
+
+func spanishStep3(w []rune, rv int) []rune {
+	suffix := longestSuffix(w, "os", "a", "o", "á", "í", "ó", "e", "é")
+	if suffix == "" || suffixStart(w, suffix) < rv {
+		return w
+	}
+	w = w[:suffixStart(w, suffix)]
+	if (suffix == "e" || suffix == "é") && hasSuffixRunes(w, "gu") && len(w)-1 >= rv {
+		w = w[:len(w)-1]
+	}
+	return w
+}
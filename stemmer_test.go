@@ -0,0 +1,108 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stemmers", func() {
+	expectStems := func(stem func(string) string, stems map[string]string) {
+		for word, expected := range stems {
+			Expect(stem(word)).To(Equal(expected), "stemming "+word)
+		}
+	}
+
+	Describe("StemEnglish", func() {
+		It("Should follow the porter2 vocabulary", func() {
+			expectStems(StemEnglish, map[string]string{
+				"consign":       "consign",
+				"consignment":   "consign",
+				"consistently":  "consist",
+				"consolatory":   "consolatori",
+				"consolidating": "consolid",
+				"conspiracy":    "conspiraci",
+				"constables":    "constabl",
+				"knackeries":    "knackeri",
+				"kneeling":      "kneel",
+				"knightly":      "knight",
+				"knitting":      "knit",
+				"knives":        "knive",
+				"generously":    "generous",
+				"skies":         "sky",
+				"cries":         "cri",
+				"ties":          "tie",
+				"it's":          "it",
+			})
+		})
+
+		It("Should reduce morphological variants to the same stem", func() {
+			Expect(StemEnglish("regulates")).To(Equal(StemEnglish("regulate")))
+			Expect(StemEnglish("regulation")).To(Equal(StemEnglish("regulate")))
+		})
+	})
+
+	Describe("StemGerman", func() {
+		It("Should follow the snowball vocabulary", func() {
+			expectStems(StemGerman, map[string]string{
+				"aufeinanderfolgenden": "aufeinanderfolg",
+				"aufeinanderfolgten":   "aufeinanderfolgt",
+				"aufeinanderschlügen":  "aufeinanderschlug",
+				"aufenthaltes":         "aufenthalt",
+				"auferstehung":         "aufersteh",
+				"häuser":               "haus",
+				"möglichkeit":          "moglich",
+				"kenntnisse":           "kenntnis",
+			})
+		})
+	})
+
+	Describe("StemSpanish", func() {
+		It("Should follow the snowball vocabulary", func() {
+			expectStems(StemSpanish, map[string]string{
+				"acabados":     "acab",
+				"acciones":     "accion",
+				"aceptación":   "acept",
+				"aceptó":       "acept",
+				"actualmente":  "actual",
+				"abiertamente": "abiert",
+				"diciéndole":   "dic",
+			})
+		})
+	})
+
+	Describe("StemIndonesian", func() {
+		It("Should remove particles, possessives, prefixes and suffixes", func() {
+			expectStems(StemIndonesian, map[string]string{
+				"menyapu":       "sapu",
+				"pembangunan":   "bangun",
+				"mempermainkan": "main",
+				"berlari":       "lari",
+				"bukunya":       "buku",
+				"kebersamaan":   "sama",
+				"dipukul":       "pukul",
+				"apakah":        "apa",
+			})
+		})
+	})
+
+	Describe("Stemmer()", func() {
+		It("Should look up built in stemmers by language name", func() {
+			Expect(Stemmer("English")).NotTo(BeNil())
+			Expect(Stemmer("klingon")).To(BeNil())
+		})
+	})
+
+	Describe("SetStemmer()", func() {
+		It("Should stem both the sentences and the dictionary", func() {
+			bag := New()
+			bag.SetStemmer(StemEnglish)
+			_, err := bag.Summarize("They regulate the market. The regulation is strict. Nobody regulates the regulators.", 1)
+			Expect(err).To(BeNil())
+			Expect(bag.Dict).To(HaveKey("regul"))
+			Expect(bag.Dict).NotTo(HaveKey("regulation"))
+			Expect(bag.BagOfWordsPerSentence[1]).To(ContainElement("regul"))
+		})
+	})
+})
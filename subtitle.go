@@ -0,0 +1,261 @@
+package tldr
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cue is a piece of text shown at a time of a video, as found in SRT and WebVTT files
+type Cue struct {
+	Start   time.Duration
+	End     time.Duration
+	Speaker string // speaker label of the cue, empty if unknown
+	Text    string // text of the cue, without markup
+}
+
+// TranscriptSummary is the result of summarizing subtitles or a transcript
+type TranscriptSummary struct {
+	Language  string
+	Sentences []*TranscriptSentence // selected sentences, in the order they are spoken
+}
+
+// TranscriptSentence is a sentence selected from subtitles, merged from one or more cues.
+// Its Start and End are byte offsets in the text of the cues joined by spaces.
+type TranscriptSentence struct {
+	*Sentence
+	StartTime time.Duration // start of the first cue of the sentence
+	EndTime   time.Duration // end of the last cue of the sentence
+	Speaker   string
+	Cues      []int // index of every cue the sentence is made of
+}
+
+var (
+	cueTiming    = regexp.MustCompile(`^\s*(\S+)\s+-->\s+(\S+)`)
+	cueTimestamp = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{1,2})[,.](\d{1,3})$`)
+	voiceSpan    = regexp.MustCompile(`<v(?:\.[^\s>]*)?\s+([^>]+)>`)
+	cueMarkup    = regexp.MustCompile(`<[^>]*>|\{\\[^}]*\}`)
+	// a name after ">>" or "-", or a name in upper case, so words like "Note:" starting a line are not speakers
+	speakerLabel = regexp.MustCompile(`^(?:(?:>>|-)\s*(\p{Lu}[\p{L}.'-]*(?: \p{Lu}[\p{L}.'-]*){0,2})|(\p{Lu}[\p{Lu}.'-]*(?: \p{Lu}[\p{Lu}.'-]*){0,2})):\s+`)
+	cueIndex     = regexp.MustCompile(`^\d+$`)
+)
+
+// ParseSRT reads the cues of a SubRip (SRT) file
+func ParseSRT(r io.Reader) ([]*Cue, error) {
+	return parseCues(r, false)
+}
+
+// ParseWebVTT reads the cues of a WebVTT file
+func ParseWebVTT(r io.Reader) ([]*Cue, error) {
+	return parseCues(r, true)
+}
+
+// ParseSubtitles reads the cues of a WebVTT file, or of an SRT file if it does not start with the WEBVTT header
+func ParseSubtitles(r io.Reader) ([]*Cue, error) {
+	reader := bufio.NewReader(r)
+	head, _ := reader.Peek(16)
+	head = bytes.TrimPrefix(head, []byte("\ufeff"))
+	return parseCues(reader, bytes.HasPrefix(head, []byte("WEBVTT")))
+}
+
+// parseCues reads the blocks of a subtitle file, separated by blank lines, and keeps the ones with a timing line.
+// The speakers of WebVTT come from voice spans, and the ones of SRT from labels like ">> Alice:", "- Alice:" or "ALICE:".
+// The speaker of a cue without one is the speaker of the cue before it.
+func parseCues(r io.Reader, vtt bool) ([]*Cue, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	var cues []*Cue
+	var block []string
+	speaker := ""
+	line := 0
+	flush := func() error {
+		defer func() { block = block[:0] }()
+		if len(block) == 0 || (vtt && isWebVTTMetadata(block[0])) {
+			return nil
+		}
+		// the identifier of the cue is optional in WebVTT, and a number in SRT
+		timing := 0
+		if !strings.Contains(block[0], "-->") && len(block) > 1 {
+			timing = 1
+		}
+		match := cueTiming.FindStringSubmatch(block[timing])
+		if match == nil {
+			return nil
+		}
+		start, err := parseTimestamp(match[1])
+		if err != nil {
+			return fmt.Errorf("tldr: line %d: %w", line-len(block)+timing+1, err)
+		}
+		end, err := parseTimestamp(match[2])
+		if err != nil {
+			return fmt.Errorf("tldr: line %d: %w", line-len(block)+timing+1, err)
+		}
+
+		cue := &Cue{Start: start, End: end}
+		text := strings.Join(block[timing+1:], "\n")
+		if voice := voiceSpan.FindStringSubmatch(text); voice != nil {
+			speaker = strings.TrimSpace(voice[1])
+		}
+		text = collapseSpaces(html.UnescapeString(cueMarkup.ReplaceAllString(text, "")))
+		if label := speakerLabel.FindStringSubmatch(text); label != nil && !vtt {
+			speaker = label[1] + label[2]
+			text = text[len(label[0]):]
+		}
+		cue.Speaker = speaker
+		cue.Text = text
+		if text != "" {
+			cues = append(cues, cue)
+		}
+		return nil
+	}
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+			if vtt {
+				if !strings.HasPrefix(text, "WEBVTT") {
+					return nil, fmt.Errorf("tldr: line 1: missing WEBVTT header")
+				}
+				continue
+			}
+		}
+		if strings.TrimSpace(text) == "" {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !vtt && len(block) > 0 && cueIndex.MatchString(strings.TrimSpace(text)) && strings.Contains(block[len(block)-1], "-->") {
+			// a missing blank line between two SRT cues
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		block = append(block, text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("tldr: reading subtitles: %w", err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return cues, nil
+}
+
+func isWebVTTMetadata(line string) bool {
+	for _, prefix := range []string{"NOTE", "STYLE", "REGION"} {
+		if line == prefix || strings.HasPrefix(line, prefix+" ") || strings.HasPrefix(line, prefix+"\t") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTimestamp parses timestamps like 01:02:03,456 (SRT) and 02:03.456 (WebVTT)
+func parseTimestamp(timestamp string) (time.Duration, error) {
+	match := cueTimestamp.FindStringSubmatch(timestamp)
+	if match == nil {
+		return 0, fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	hours, _ := strconv.Atoi("0" + match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	millis, _ := strconv.Atoi((match[4] + "00")[:3])
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(millis)*time.Millisecond, nil
+}
+
+// SummarizeSubtitles summarizes an SRT or WebVTT file to num sentences, see SummarizeCues
+func (bag *Bag) SummarizeSubtitles(r io.Reader, num int) (*TranscriptSummary, error) {
+	cues, err := ParseSubtitles(r)
+	if err != nil {
+		return nil, err
+	}
+	return bag.SummarizeCues(cues, num)
+}
+
+// SummarizeCues merges the cues into sentences and summarizes them to num sentences.
+// A change of speaker always ends a sentence, and every sentence keeps the time span of its cues.
+func (bag *Bag) SummarizeCues(cues []*Cue, num int) (*TranscriptSummary, error) {
+	// text of the cues joined by spaces, and where every cue starts in it
+	var b strings.Builder
+	starts := make([]int, len(cues))
+	for i, cue := range cues {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		starts[i] = b.Len()
+		b.WriteString(cue.Text)
+	}
+	joined := b.String()
+	cueAt := func(offset int) int {
+		return sort.Search(len(starts), func(i int) bool { return starts[i] > offset }) - 1
+	}
+
+	pre := Preprocess(joined, bag.preprocessors...)
+	bag.resolveLanguage(pre.Text)
+
+	var sentences []string
+	var spans [][2]int // first and last cue of every sentence
+	for from := 0; from < len(cues); {
+		to := from + 1
+		for to < len(cues) && cues[to].Speaker == cues[from].Speaker {
+			to++
+		}
+
+		// the turn of the speaker, from the start of its first cue to the end of its last one
+		turnStart := starts[from]
+		turnEnd := starts[to-1] + len(cues[to-1].Text)
+		text := pre.Text[preprocessedOffset(pre, turnStart):preprocessedOffset(pre, turnEnd)]
+		at := preprocessedOffset(pre, turnStart)
+		for _, sentence := range bag.splitBlock(text) {
+			start := strings.Index(pre.Text[at:], sentence)
+			if start < 0 {
+				continue
+			}
+			start += at
+			at = start + len(sentence)
+			sentences = append(sentences, sentence)
+			spans = append(spans, [2]int{cueAt(pre.OriginalOffset(start)), cueAt(pre.OriginalOffset(at) - 1)})
+		}
+		from = to
+	}
+
+	idx, err := bag.summarizeSentences(pre, sentences, nil, num)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &TranscriptSummary{
+		Language: bag.languageName(),
+	}
+	for _, sentence := range bag.summarySentences(idx) {
+		span := spans[sentence.Index]
+		selected := &TranscriptSentence{
+			Sentence:  sentence,
+			StartTime: cues[span[0]].Start,
+			EndTime:   cues[span[1]].End,
+			Speaker:   cues[span[0]].Speaker,
+		}
+		for i := span[0]; i <= span[1]; i++ {
+			selected.Cues = append(selected.Cues, i)
+		}
+		summary.Sentences = append(summary.Sentences, selected)
+	}
+	return summary, nil
+}
+
+// preprocessedOffset finds the offset in the preprocessed text of the first byte coming from offset or after it
+func preprocessedOffset(pre *PreprocessedText, offset int) int {
+	return sort.Search(len(pre.Text), func(i int) bool { return pre.OriginalOffset(i) >= offset })
+}
@@ -0,0 +1,148 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+	"time"
+)
+
+const srt = `1
+00:00:01,000 --> 00:00:03,500
+ALICE: Welcome to the planning meeting for the
+
+2
+00:00:03,500 --> 00:00:06,000
+<i>new mobile release</i> of the app.
+
+3
+00:00:06,200 --> 00:00:09,000
+The release will ship the offline mode and the new sync engine.
+
+4
+00:00:09,500 --> 00:00:12,000
+BOB: The sync engine still fails on slow networks,
+
+5
+00:00:12,000 --> 00:00:15,250
+so the release date of the mobile app may move.
+
+6
+00:00:15,500 --> 00:00:17,000
+Thanks for coming.
+`
+
+const vtt = `WEBVTT
+Kind: captions
+
+NOTE This comment is not a cue
+
+intro
+00:01.000 --> 00:03.500 align:start
+<v Alice>Welcome to the planning meeting for the</v>
+
+00:03.500 --> 00:06.000
+<v Alice>new mobile release of the app &amp; the web.</v>
+
+00:06.200 --> 01:00:09.000
+<v.loud Bob>The sync engine still fails on slow networks.</v>
+`
+
+var _ = Describe("Subtitles", func() {
+	Describe("ParseSRT()", func() {
+		It("Should read the cues with their speakers and without markup", func() {
+			cues, err := ParseSRT(strings.NewReader(srt))
+			Expect(err).To(BeNil())
+			Expect(cues).To(HaveLen(6))
+			Expect(cues[0].Start).To(Equal(time.Second))
+			Expect(cues[0].End).To(Equal(3500 * time.Millisecond))
+			Expect(cues[0].Speaker).To(Equal("ALICE"))
+			Expect(cues[0].Text).To(Equal("Welcome to the planning meeting for the"))
+			Expect(cues[1].Text).To(Equal("new mobile release of the app."))
+			Expect(cues[2].Speaker).To(Equal("ALICE"))
+			Expect(cues[3].Speaker).To(Equal("BOB"))
+		})
+
+		It("Should only take the usual labels for speakers", func() {
+			cues, err := ParseSRT(strings.NewReader("1\n00:00:01,000 --> 00:00:02,000\n>> Alice: We ship on Friday.\n\n" +
+				"2\n00:00:02,000 --> 00:00:03,000\nNote: the build is green now.\n\n" +
+				"3\n00:00:03,000 --> 00:00:04,000\nUpdate: the release notes are ready too.\n\n" +
+				"4\n00:00:04,000 --> 00:00:05,000\n- Bob: Great.\n"))
+			Expect(err).To(BeNil())
+			Expect(cues).To(HaveLen(4))
+			Expect(cues[0].Speaker).To(Equal("Alice"))
+			Expect(cues[0].Text).To(Equal("We ship on Friday."))
+			Expect(cues[1].Speaker).To(Equal("Alice"))
+			Expect(cues[1].Text).To(Equal("Note: the build is green now."))
+			Expect(cues[2].Speaker).To(Equal("Alice"))
+			Expect(cues[2].Text).To(Equal("Update: the release notes are ready too."))
+			Expect(cues[3].Speaker).To(Equal("Bob"))
+		})
+
+		It("Should report invalid timestamps", func() {
+			_, err := ParseSRT(strings.NewReader("1\n00:00:01,000 --> 00:0x:03,500\nHello.\n"))
+			Expect(err).To(MatchError(ContainSubstring("line 2")))
+		})
+	})
+
+	Describe("ParseWebVTT()", func() {
+		It("Should read the cues and skip the header and notes", func() {
+			cues, err := ParseSubtitles(strings.NewReader(vtt))
+			Expect(err).To(BeNil())
+			Expect(cues).To(HaveLen(3))
+			Expect(cues[0].Speaker).To(Equal("Alice"))
+			Expect(cues[1].Text).To(Equal("new mobile release of the app & the web."))
+			Expect(cues[2].Speaker).To(Equal("Bob"))
+			Expect(cues[2].End).To(Equal(time.Hour + 9*time.Second))
+		})
+
+		It("Should only take speakers from voice spans", func() {
+			cues, err := ParseWebVTT(strings.NewReader("WEBVTT\n\n00:01.000 --> 00:02.000\nNOTE: the build is green now.\n"))
+			Expect(err).To(BeNil())
+			Expect(cues).To(HaveLen(1))
+			Expect(cues[0].Speaker).To(BeEmpty())
+			Expect(cues[0].Text).To(Equal("NOTE: the build is green now."))
+		})
+
+		It("Should require the header", func() {
+			_, err := ParseWebVTT(strings.NewReader(srt))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("SummarizeSubtitles()", func() {
+		It("Should merge cues into sentences with their timestamps and speakers", func() {
+			summary, err := New().SummarizeSubtitles(strings.NewReader(srt), 4)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences).To(HaveLen(4))
+
+			first := summary.Sentences[0]
+			Expect(first.Text).To(Equal("Welcome to the planning meeting for the new mobile release of the app."))
+			Expect(first.StartTime).To(Equal(time.Second))
+			Expect(first.EndTime).To(Equal(6 * time.Second))
+			Expect(first.Speaker).To(Equal("ALICE"))
+			Expect(first.Cues).To(Equal([]int{0, 1}))
+
+			bob := summary.Sentences[2]
+			Expect(bob.Text).To(Equal("The sync engine still fails on slow networks, so the release date of the mobile app may move."))
+			Expect(bob.Speaker).To(Equal("BOB"))
+			Expect(bob.StartTime).To(Equal(9500 * time.Millisecond))
+			Expect(bob.EndTime).To(Equal(15250 * time.Millisecond))
+		})
+
+		It("Should end sentences when the speaker changes", func() {
+			cues := []*Cue{
+				{Start: 0, End: time.Second, Speaker: "A", Text: "So we agreed on the plan"},
+				{Start: time.Second, End: 2 * time.Second, Speaker: "B", Text: "Yes we did agree on the plan."},
+			}
+			summary, err := New().SummarizeCues(cues, 2)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences).To(HaveLen(2))
+			Expect(summary.Sentences[0].Text).To(Equal("So we agreed on the plan"))
+			Expect(summary.Sentences[1].Speaker).To(Equal("B"))
+		})
+	})
+})
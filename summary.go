@@ -0,0 +1,80 @@
+package tldr
+
+import (
+	"strings"
+)
+
+// Summary is the detailed result of summarizing a text
+type Summary struct {
+	Language  string      `json:"language,omitempty"` // name of the language used to process the text, empty if none
+	Sentences []*Sentence `json:"sentences"`          // selected sentences, in the order they appear in the text
+}
+
+// Sentence is a sentence selected into a summary
+type Sentence struct {
+	Index int     `json:"index"` // position of the sentence in OriginalSentences
+	Text  string  `json:"text"`  // the sentence, truncated if MaxCharacters is reached
+	Start int     `json:"start"` // byte offset where the sentence starts in the summarized text, -1 if unknown
+	End   int     `json:"end"`   // byte offset where the sentence ends in the summarized text, -1 if unknown
+	Score float64 `json:"score"` // score of the sentence, the highest of the text is 1
+}
+
+// SummarizeDetailed works like Summarize, but also reports the language
+// used to process the text and where each selected sentence came from
+func (bag *Bag) SummarizeDetailed(text string, num int) (*Summary, error) {
+	idx, err := bag.summarize(text, num)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Language: bag.languageName(),
+	}
+	if idx == nil {
+		return summary, nil
+	}
+
+	summary.Sentences = bag.summarySentences(idx)
+	return summary, nil
+}
+
+// summarySentences builds the sentences at idx selected in the last run
+func (bag *Bag) summarySentences(idx []int) []*Sentence {
+	spans := bag.sentenceSpans()
+	idx, texts := bag.truncate(idx)
+	sentences := make([]*Sentence, 0, len(idx))
+	for i, sentence := range texts {
+		selected := &Sentence{
+			Index: idx[i],
+			Text:  sentence,
+			Start: spans[idx[i]][0],
+			End:   spans[idx[i]][1],
+		}
+		if idx[i] < len(bag.scores) {
+			selected.Score = bag.scores[idx[i]]
+		}
+		sentences = append(sentences, selected)
+	}
+	return sentences
+}
+
+// sentenceSpans locates every sentence of OriginalSentences in the text of the last run,
+// mapped back to the text before preprocessing. Sentences not found are at -1.
+func (bag *Bag) sentenceSpans() [][2]int {
+	spans := make([][2]int, len(bag.OriginalSentences))
+	from := 0
+	for i, sentence := range bag.OriginalSentences {
+		spans[i] = [2]int{-1, -1}
+		if bag.source == nil || sentence == "" {
+			continue
+		}
+		at := strings.Index(bag.source.Text[from:], sentence)
+		if at < 0 {
+			continue
+		}
+		start := from + at
+		from = start + len(sentence)
+		spans[i] = [2]int{bag.source.OriginalOffset(start), bag.source.OriginalOffset(from)}
+	}
+	return spans
+}
@@ -0,0 +1,44 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+)
+
+var _ = Describe("SummarizeDetailed()", func() {
+	It("Should return the same sentences as Summarize along with their index", func() {
+		sums, err := New().Summarize(text, 3)
+		Expect(err).To(BeNil())
+
+		bag := New()
+		summary, err := bag.SummarizeDetailed(text, 3)
+		Expect(err).To(BeNil())
+		Expect(summary.Sentences).To(HaveLen(3))
+		for i, sentence := range summary.Sentences {
+			Expect(sentence.Text).To(Equal(sums[i]))
+			Expect(bag.OriginalSentences[sentence.Index]).To(Equal(sentence.Text))
+		}
+	})
+
+	It("Should return an empty summary for an empty text", func() {
+		summary, err := New().SummarizeDetailed("  ", 3)
+		Expect(err).To(BeNil())
+		Expect(summary.Sentences).To(BeEmpty())
+	})
+
+	It("Should keep the sentences truncated by MaxCharacters", func() {
+		bag := New()
+		bag.MaxCharacters = 100
+		summary, err := bag.SummarizeDetailed(text, 3)
+		Expect(err).To(BeNil())
+		texts := []string{}
+		for _, sentence := range summary.Sentences {
+			texts = append(texts, sentence.Text)
+		}
+		Expect(len([]rune(strings.Join(texts, "")))).To(BeNumerically("<=", 100))
+	})
+})
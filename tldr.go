@@ -10,7 +10,6 @@ import (
 	"encoding/json"
 	"sort"
 	"strings"
-	"unicode"
 
 	"github.com/alixaxel/pagerank"
 )
@@ -30,12 +29,38 @@ type Bag struct {
 	Tolerance                  float64
 	Threshold                  float64
 	SentencesDistanceThreshold float64
-
-	customAlgorithm func(e []*Edge) []int
-	customWeighing  func(src, dst []int) float64
-	wordTokenizer   func(sentence string) []string
+	Language                   string  // "" for none, "auto" to detect it, or a name or code from Languages
+	WordNGrams                 int     // longest word n-gram added to the sentence vectors, 1 for single words only
+	CharNGrams                 int     // length of the character n-grams added to the sentence vectors, 0 for none
+	HeadingWeight              float64 // boost of Markdown sentences sharing words with their section headings, 0 for none
+	ReplyWeight                float64 // boost of the chat messages with the most replies, 0 for none
+	ReactionWeight             float64 // boost of the chat messages with the most reactions, 0 for none
+	PositionWeight             float64 // boost of the sentences opening a paragraph of a Document, 0 for none
+	Ratio                      float64 // share of the sentences kept in the summary, overrides the number of sentences, 0 for none
+	MaxWords                   int     // maximum number of words of the summary, 0 for no limit
+	MaxTokens                  int     // maximum number of tokens of the summary, see SetTokenCounter, 0 for no limit
+	Truncation                 string  // handling of the sentence overflowing MaxCharacters: "cut", "word", "drop" or "skip"
+	Ellipsis                   string  // appended to the sentences cut by MaxCharacters, counted in it
+	Separator                  string  // put between the sentences of the summary by the caller, counted in MaxCharacters
+	KeywordAlgorithm           string  // algorithm of ExtractKeywords: "textrank" or "yake"
+
+	customAlgorithm   func(e []*Edge) []int
+	customWeighing    func(src, dst []int) float64
+	wordTokenizer     func(sentence string) []string
+	stemmer           func(word string) string
+	stopWords         map[string]bool
+	sentenceTokenizer func(text string) []string
+	preprocessors     []Preprocessor
+	tokenCounter      func(text string) int
 
 	vectorLength int
+	dictCreated  bool // Dict was created from the last text, not provided by the user
+
+	featuresPerSentence [][]string        // words and n-grams of every sentence, in the vectors
+	lastSentences       []string          // OriginalSentences of the last run, never summarized again for an empty text
+	scores              []float64         // score of every sentence in the last run, the highest is 1
+	language            *Language         // resolved from Language for the current run
+	source              *PreprocessedText // text of the current run
 }
 
 func (b *Bag) String() string {
@@ -53,6 +78,20 @@ const (
 	DEFAULT_THRESHOLD                    = 0.001
 	DEFAULT_MAX_CHARACTERS               = 0
 	DEFAULT_SENTENCES_DISTANCE_THRESHOLD = 0.95
+	DEFAULT_LANGUAGE                     = ""
+	DEFAULT_WORD_NGRAMS                  = 1
+	DEFAULT_CHAR_NGRAMS                  = 0
+	DEFAULT_HEADING_WEIGHT               = 0
+	DEFAULT_REPLY_WEIGHT                 = 0
+	DEFAULT_REACTION_WEIGHT              = 0
+	DEFAULT_POSITION_WEIGHT              = 0
+	DEFAULT_RATIO                        = 0
+	DEFAULT_MAX_WORDS                    = 0
+	DEFAULT_MAX_TOKENS                   = 0
+	DEFAULT_TRUNCATION                   = TRUNCATION_CUT
+	DEFAULT_ELLIPSIS                     = ""
+	DEFAULT_SEPARATOR                    = ""
+	DEFAULT_KEYWORD_ALGORITHM            = KEYWORDS_TEXTRANK
 )
 
 func defaultWordTokenizer(sentence string) []string {
@@ -73,6 +112,20 @@ func New() *Bag {
 		Tolerance:                  DEFAULT_TOLERANCE,
 		Threshold:                  DEFAULT_THRESHOLD,
 		SentencesDistanceThreshold: DEFAULT_SENTENCES_DISTANCE_THRESHOLD,
+		Language:                   DEFAULT_LANGUAGE,
+		WordNGrams:                 DEFAULT_WORD_NGRAMS,
+		CharNGrams:                 DEFAULT_CHAR_NGRAMS,
+		HeadingWeight:              DEFAULT_HEADING_WEIGHT,
+		ReplyWeight:                DEFAULT_REPLY_WEIGHT,
+		ReactionWeight:             DEFAULT_REACTION_WEIGHT,
+		PositionWeight:             DEFAULT_POSITION_WEIGHT,
+		Ratio:                      DEFAULT_RATIO,
+		MaxWords:                   DEFAULT_MAX_WORDS,
+		MaxTokens:                  DEFAULT_MAX_TOKENS,
+		Truncation:                 DEFAULT_TRUNCATION,
+		Ellipsis:                   DEFAULT_ELLIPSIS,
+		Separator:                  DEFAULT_SEPARATOR,
+		KeywordAlgorithm:           DEFAULT_KEYWORD_ALGORITHM,
 		wordTokenizer:              defaultWordTokenizer,
 	}
 }
@@ -92,6 +145,7 @@ func (bag *Bag) Set(m int, d, t, th, sth float64, alg, w string) {
 // Dictionary is a map[string]int where the key is the word and int is the position in vector, starting from 1
 func (bag *Bag) SetDictionary(dict map[string]int) {
 	bag.Dict = dict
+	bag.dictCreated = false
 }
 
 func (bag *Bag) SetCustomAlgorithm(f func(e []*Edge) []int) {
@@ -106,35 +160,113 @@ func (bag *Bag) SetWordTokenizer(f func(string) []string) {
 	bag.wordTokenizer = f
 }
 
-// Summarize the text to num sentences
+// Summarize the text to num sentences.
+// Setting OriginalSentences and summarizing an empty text still works, but SummarizeSentences should be used instead.
 func (bag *Bag) Summarize(text string, num int) ([]string, error) {
-	text = strings.TrimSpace(text)
-	if len(text) < 1 && len(bag.OriginalSentences) == 0 {
+	idx, err := bag.summarize(text, num)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+
+	return bag.concatResult(idx), nil
+}
+
+// SummarizeSentences summarizes sentences split beforehand to num of them, without splitting them again
+func (bag *Bag) SummarizeSentences(sentences []string, num int) ([]string, error) {
+	bag.resolveLanguage(strings.Join(sentences, " "))
+	idx, err := bag.summarizeSentences(nil, sentences, nil, num)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+
+	return bag.concatResult(idx), nil
+}
+
+// SummarizeTokenized summarizes sentences already split into words to num of them,
+// returned with their words joined by spaces. The word tokenizer is not used, but stop words and stemmer are.
+func (bag *Bag) SummarizeTokenized(sentences [][]string, num int) ([]string, error) {
+	joined := make([]string, len(sentences))
+	for i, words := range sentences {
+		joined[i] = strings.Join(words, " ")
+	}
+	bag.resolveLanguage(strings.Join(joined, " "))
+
+	bag.source = nil
+	bag.OriginalSentences = joined
+	if len(joined) == 0 {
+		return nil, nil
+	}
+	words := make([][]string, len(sentences))
+	for i := range sentences {
+		words[i] = bag.normalizeWords(append([]string(nil), sentences[i]...))
+	}
+	bag.setWords(words)
+
+	idx, err := bag.rank(num, nil)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+
+	return bag.concatResult(idx), nil
+}
+
+// summarize ranks the sentences of text and returns the index of the top num of them,
+// sorted by how they appear in the text
+func (bag *Bag) summarize(text string, num int) ([]int, error) {
+	bag.source = Preprocess(text, bag.preprocessors...)
+	text = strings.TrimSpace(bag.source.Text)
+	if len(text) > 0 {
+		// never reuse the sentences of the last call
+		bag.OriginalSentences = nil
+	} else if !bag.givenSentences() {
+		bag.OriginalSentences, bag.Ranks = nil, nil
 		return nil, nil
 	}
 
+	bag.resolveLanguage(text)
 	bag.createSentences(text) // only actually creates sentences if no OrignalSentences
 
+	return bag.rank(num, nil)
+}
+
+// givenSentences tells if OriginalSentences were set by the caller, rather than left by the last run
+func (bag *Bag) givenSentences() bool {
+	given, last := bag.OriginalSentences, bag.lastSentences
+	return len(given) > 0 && (len(given) != len(last) || &given[0] != &last[0])
+}
+
+// summarizeSentences works like summarize for sentences split beforehand out of source, which can be nil.
+// The language must already be resolved. Boosts, if any, are added to the normalized score of each sentence.
+func (bag *Bag) summarizeSentences(source *PreprocessedText, sentences []string, boosts []float64, num int) ([]int, error) {
+	bag.source = source
+	bag.OriginalSentences = sentences
+	if len(sentences) == 0 {
+		return nil, nil
+	}
+
+	bag.createSentences("")
+
+	return bag.rank(num, boosts)
+}
+
+// rank the sentences and return the index of the top num of them, sorted by how they appear in the text
+func (bag *Bag) rank(num int, boosts []float64) ([]int, error) {
 	// If user already provide dictionary, pass creating dictionary
-	if len(bag.Dict) < 1 {
-		if text == "" {
-			text = strings.TrimSpace(strings.Join(bag.OriginalSentences, " "))
-		}
-		bag.createDictionary(text)
+	if len(bag.Dict) < 1 || bag.dictCreated {
+		bag.createDictionary()
 	}
 
 	bag.createNodes()
-	bag.createEdges()
 
-	switch bag.Algorithm {
-	case "centrality":
-		bag.centrality()
-	case "pagerank":
-		bag.pageRank()
-	case "custom":
-		bag.Ranks = bag.customAlgorithm(bag.Edges)
-	default:
-		bag.pageRank()
+	return bag.rankNodes(num, boosts)
+}
+
+// rankNodes ranks the nodes already created, see rank
+func (bag *Bag) rankNodes(num int, boosts []float64) ([]int, error) {
+	bag.lastSentences = bag.OriginalSentences
+	bag.scoreNodes()
+	if boosts != nil {
+		bag.boost(boosts)
 	}
 
 	// if no ranks, return error
@@ -143,46 +275,36 @@ func (bag *Bag) Summarize(text string, num int) ([]string, error) {
 		return nil, nil
 	}
 
-	// guard so it won't crash but return only the highest rank sentence
-	// if num is invalid
-	if num > lenRanks || num < 1 {
-		num = 1
-	}
-
-	// get only top num of ranks
-	idx := bag.Ranks[:num]
+	// get only top num of ranks, or the ones fitting in the length limits
+	idx := bag.selectSentences(num)
 	// sort it ascending by how the sentences appeared on the original text
 	sort.Ints(idx)
 
-	return bag.concatResult(idx), nil
+	return idx, nil
 }
 
-// concatenate sentences at idx to result string
-func (bag *Bag) concatResult(idx []int) []string {
-	var res []string
-	if bag.MaxCharacters > 0 {
-		lenRes := 0
-		for i := range idx {
-			lenOrig := len([]rune(bag.OriginalSentences[idx[i]]))
-			if lenRes+lenOrig <= bag.MaxCharacters {
-				res = append(res, bag.OriginalSentences[idx[i]])
-			} else {
-				n := bag.MaxCharacters - lenRes
-				if n > lenOrig {
-					n = lenOrig
-				}
-				res = append(res, string([]rune(bag.OriginalSentences[idx[i]])[:n]))
-				break
-			}
-			lenRes += lenOrig
-		}
-		return res
-	}
+// scoreNodes ranks every node already created with the algorithm of the bag, the highest score being 1
+func (bag *Bag) scoreNodes() {
+	bag.createEdges()
 
-	for i := range idx {
-		res = append(res, bag.OriginalSentences[idx[i]])
+	switch bag.Algorithm {
+	case "centrality":
+		bag.centrality()
+	case "pagerank":
+		bag.pageRank()
+	case "custom":
+		bag.Ranks = bag.customAlgorithm(bag.Edges)
+		bag.scoreByPosition()
+	default:
+		bag.pageRank()
 	}
 
+	bag.normalizeScores()
+}
+
+// concatenate sentences at idx to result string, truncated to MaxCharacters
+func (bag *Bag) concatResult(idx []int) []string {
+	_, res := bag.truncate(idx)
 	return res
 }
 
@@ -209,10 +331,12 @@ func (bag *Bag) centrality() {
 	seen := make(map[int]bool, len(newEdges)/4) // Estimate quarter are unique
 	ranks := make([]int, 0, len(newEdges)/4)     // Pre-allocate result
 
+	bag.scores = make([]float64, len(bag.Nodes))
 	for _, edge := range newEdges {
 		if !seen[edge.src] {
 			seen[edge.src] = true
 			ranks = append(ranks, edge.src)
+			bag.scores[edge.src] = edge.weight
 		}
 	}
 
@@ -248,13 +372,53 @@ func (bag *Bag) pageRank() {
 
 	// Pre-allocate result slice
 	idx := make([]int, len(ranks))
+	bag.scores = make([]float64, len(bag.Nodes))
 	for i, v := range ranks {
 		idx[i] = v.idx
+		bag.scores[v.idx] = v.score
 	}
 
 	bag.Ranks = idx
 }
 
+// scoreByPosition scores the sentences ranked by a custom algorithm by their position in the ranks
+func (bag *Bag) scoreByPosition() {
+	bag.scores = make([]float64, len(bag.Nodes))
+	for i, idx := range bag.Ranks {
+		if idx >= 0 && idx < len(bag.scores) {
+			bag.scores[idx] = float64(len(bag.Ranks)-i) / float64(len(bag.Ranks))
+		}
+	}
+}
+
+// normalizeScores scales the scores so the highest one is 1
+func (bag *Bag) normalizeScores() {
+	max := 0.0
+	for _, score := range bag.scores {
+		if score > max {
+			max = score
+		}
+	}
+	if max == 0 {
+		return
+	}
+	for i := range bag.scores {
+		bag.scores[i] /= max
+	}
+}
+
+// boost adds boosts to the scores of the sentences and sorts the ranks by the new scores
+func (bag *Bag) boost(boosts []float64) {
+	for i := range bag.scores {
+		if i < len(boosts) {
+			bag.scores[i] += boosts[i]
+		}
+	}
+	sort.SliceStable(bag.Ranks, func(i, j int) bool {
+		return bag.scores[bag.Ranks[i]] > bag.scores[bag.Ranks[j]]
+	})
+}
+
 type Edge struct {
 	src    int     // index of node
 	dst    int     // index of node
@@ -276,6 +440,10 @@ func (bag *Bag) createEdges() {
 			// don't compare same node
 			if i != j {
 				var weight float64
+				if src.vector == nil {
+					bag.Edges = append(bag.Edges, &Edge{i, j, bag.sparseWeight(src.features, dst.features)})
+					continue
+				}
 				switch weighing {
 				case "jaccard":
 					// Inline calculation to avoid function call overhead
@@ -327,14 +495,15 @@ type Node struct {
 		str = "I am not shit, you effin shit"
 		vector = [1, 1, 0, 2] => [1, 1, 0, 1] because should be binary
 	*/
+	features []int // sorted dict positions of the words of the sentence, used when vector is nil
 }
 
 func (bag *Bag) createNodes() {
 	bag.vectorLength = len(bag.Dict)
 	// Pre-allocate nodes slice to avoid multiple allocations
-	bag.Nodes = make([]*Node, 0, len(bag.BagOfWordsPerSentence))
+	bag.Nodes = make([]*Node, 0, len(bag.featuresPerSentence))
 
-	for i, sentence := range bag.BagOfWordsPerSentence {
+	for i, sentence := range bag.featuresPerSentence {
 		// vector length is len(dict)
 		vector := make([]int, bag.vectorLength)
 		// word for word now
@@ -346,7 +515,7 @@ func (bag *Bag) createNodes() {
 			}
 		}
 		// vector is now created, put it into the node
-		bag.Nodes = append(bag.Nodes, &Node{i, vector})
+		bag.Nodes = append(bag.Nodes, &Node{i, vector, nil})
 	}
 }
 
@@ -356,48 +525,42 @@ func (bag *Bag) createSentences(text string) {
 		// done by calling func: text = strings.TrimSpace(text)
 		// tokenize text as sentences
 		// sentence is a group of words separated by whitespaces or punctuation other than !?.
-		bag.OriginalSentences = TokenizeSentences(text)
+		bag.OriginalSentences = bag.tokenizeSentences(text)
 	}
 
 	// from original sentences, explode each sentences into bag of words
 	// Pre-allocate to avoid multiple allocations
-	bag.BagOfWordsPerSentence = make([][]string, 0, len(bag.OriginalSentences))
+	words := make([][]string, 0, len(bag.OriginalSentences))
 	for _, sentence := range bag.OriginalSentences {
-		words := bag.wordTokenizer(sentence)
-		bag.BagOfWordsPerSentence = append(bag.BagOfWordsPerSentence, words)
+		words = append(words, bag.normalizeWords(bag.wordTokenizer(sentence)))
 	}
 
+	bag.setWords(words)
+}
+
+// setWords sets the normalized words of every sentence, then removes near duplicates and creates the features
+func (bag *Bag) setWords(words [][]string) {
+	bag.BagOfWordsPerSentence = words
+
 	// then uniq it
 	UniqSentences(bag.BagOfWordsPerSentence, bag.SentencesDistanceThreshold)
+
+	bag.createFeatures()
 }
 
-func (bag *Bag) createDictionary(text string) {
-	// trim all spaces
-	// this already done by calling func:	text = strings.TrimSpace(text)
-	// lowercase the text
-	text = strings.ToLower(text)
-	// remove all non alphanumerics but spaces
-	var prev rune
-	text = strings.Map(func(r rune) rune {
-		if r == '-' && (unicode.IsDigit(prev) || unicode.IsLetter(prev)) {
-			return r
-		}
-		if !unicode.IsDigit(r) && !unicode.IsLetter(r) && !unicode.IsSpace(r) {
-			return -1
-		}
-		prev = r
-		return r
-	}, text)
-	// turn it into bag of words
-	words := strings.Fields(text)
-	// turn it into dictionary
+// createDictionary gives every distinct word and n-gram of the sentences a position in the vectors,
+// so the dictionary always agrees with the word tokenizer, stop words and stemmer
+func (bag *Bag) createDictionary() {
 	dict := make(map[string]int)
 	i := 1
-	for _, word := range words {
-		if dict[word] == 0 {
-			dict[word] = i
-			i++
+	for _, words := range bag.featuresPerSentence {
+		for _, word := range words {
+			if word != "" && dict[word] == 0 {
+				dict[word] = i
+				i++
+			}
 		}
 	}
 	bag.Dict = dict
+	bag.dictCreated = true
 }
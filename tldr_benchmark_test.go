@@ -3,6 +3,8 @@ package tldr_test
 import (
 	"github.com/didasy/tldr"
 	"io/ioutil"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -76,3 +78,37 @@ func BenchmarkSummarizePagerankJaccard(b *testing.B) {
 
 	resultText = rtxt
 }
+
+func BenchmarkSummarizeReaderPagerankHamming(b *testing.B) {
+	var rtxt []string
+
+	for n := 0; n < b.N; n++ {
+		benchSummarizer = tldr.New()
+		benchSummarizer.Algorithm = "pagerank"
+		benchSummarizer.Weighing = "hamming"
+		rtxt, _ = benchSummarizer.SummarizeReader(strings.NewReader(benchText), NUM_SENTENCES)
+	}
+
+	resultText = rtxt
+}
+
+func BenchmarkSummarizeReaderLarge(b *testing.B) {
+	var rtxt []string
+	large := strings.Repeat(benchText+"\n", 100)
+	var sb strings.Builder
+	for i, sentence := range strings.SplitAfter(large, ". ") {
+		// numbered so no sentence is skipped as a duplicate
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteString(" ")
+		sb.WriteString(sentence)
+	}
+	large = sb.String()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		benchSummarizer = tldr.New()
+		rtxt, _ = benchSummarizer.SummarizeReader(strings.NewReader(large), NUM_SENTENCES)
+	}
+
+	resultText = rtxt
+}
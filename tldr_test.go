@@ -161,4 +161,69 @@ var _ = Describe("tldr", func() {
 			})
 		})
 	})
+
+	Describe("Test summarizing sentences split beforehand", func() {
+		sentences := []string{
+			"Mary had a little lamb,",
+			"it's fleece was white as snow,",
+			"and everywhere that Mary went,",
+			"that lamb was sure to go.",
+		}
+
+		It("Should summarize them without splitting them again", func() {
+			summarizer = New()
+			sums, err := summarizer.SummarizeSentences(sentences, 1)
+			Expect(err).To(BeNil())
+			Expect(sums).To(Equal([]string{"it's fleece was white as snow,"}))
+			Expect(summarizer.OriginalSentences).To(Equal(sentences))
+		})
+
+		It("Should summarize sentences already split into words", func() {
+			words := make([][]string, 0, len(sentences))
+			for _, sentence := range sentences {
+				words = append(words, strings.Fields(strings.ToLower(sentence)))
+			}
+			summarizer = New()
+			sums, err := summarizer.SummarizeTokenized(words, 1)
+			Expect(err).To(BeNil())
+			Expect(sums).To(Equal([]string{"it's fleece was white as snow,"}))
+			Expect(words[0]).To(Equal([]string{"mary", "had", "a", "little", "lamb,"}))
+		})
+
+		It("Should not leak the sentences into the next text", func() {
+			summarizer = New()
+			_, err := summarizer.SummarizeSentences(sentences, 2)
+			Expect(err).To(BeNil())
+			sums, err := summarizer.Summarize(text, 3)
+			Expect(err).To(BeNil())
+			Expect(strings.Join(sums, "\n\n")).To(Equal(strings.TrimSpace(result)))
+
+			sums, err = summarizer.Summarize("Birds sing songs. Fish swim fast. Birds fly high.", 1)
+			Expect(err).To(BeNil())
+			Expect(summarizer.OriginalSentences).To(HaveLen(3))
+			Expect(sums).To(HaveLen(1))
+		})
+
+		It("Should not summarize the sentences of the last call again for an empty text", func() {
+			summarizer = New()
+			_, err := summarizer.SummarizeSentences(sentences, 2)
+			Expect(err).To(BeNil())
+			sums, err := summarizer.Summarize("", 2)
+			Expect(err).To(BeNil())
+			Expect(sums).To(BeEmpty())
+
+			words := [][]string{{"birds", "sing"}, {"fish", "swim"}}
+			_, err = summarizer.SummarizeTokenized(words, 1)
+			Expect(err).To(BeNil())
+			summary, err := summarizer.SummarizeDetailed("  ", 1)
+			Expect(err).To(BeNil())
+			Expect(summary.Sentences).To(BeEmpty())
+
+			// sentences set by the caller are still summarized
+			summarizer.OriginalSentences = append([]string(nil), sentences...)
+			sums, err = summarizer.Summarize("", 1)
+			Expect(err).To(BeNil())
+			Expect(sums).To(Equal([]string{"it's fleece was white as snow,"}))
+		})
+	})
 })
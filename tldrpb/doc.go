@@ -0,0 +1,5 @@
+// Package tldrpb holds the protobuf messages and the gRPC service of tldr, generated from tldr.proto.
+// The service is implemented by the grpcserver package.
+package tldrpb
+
+//go:generate protoc --proto_path=.. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative ../tldrpb/tldr.proto
@@ -0,0 +1,729 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: tldrpb/tldr.proto
+
+package tldrpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Settings override the defaults of the server for one request.
+type Settings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "pagerank" or "centrality".
+	Algorithm *string `protobuf:"bytes,1,opt,name=algorithm,proto3,oneof" json:"algorithm,omitempty"`
+	// "hamming" or "jaccard".
+	Weighing *string `protobuf:"bytes,2,opt,name=weighing,proto3,oneof" json:"weighing,omitempty"`
+	// "" for none, "auto" to detect it, or a language name or code.
+	Language *string `protobuf:"bytes,3,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	// Maximum number of characters of the summary, 0 for no limit.
+	MaxCharacters *int32   `protobuf:"varint,4,opt,name=max_characters,json=maxCharacters,proto3,oneof" json:"max_characters,omitempty"`
+	Damping       *float64 `protobuf:"fixed64,5,opt,name=damping,proto3,oneof" json:"damping,omitempty"`
+	Tolerance     *float64 `protobuf:"fixed64,6,opt,name=tolerance,proto3,oneof" json:"tolerance,omitempty"`
+	Threshold     *float64 `protobuf:"fixed64,7,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+	// Preset applied before the other settings: "news", "legal", "chat" or "short-review".
+	Preset *string `protobuf:"bytes,8,opt,name=preset,proto3,oneof" json:"preset,omitempty"`
+	// Maximum number of words of the summary, 0 for no limit.
+	MaxWords *int32 `protobuf:"varint,9,opt,name=max_words,json=maxWords,proto3,oneof" json:"max_words,omitempty"`
+	// Maximum number of estimated language model tokens of the summary, 0 for no limit.
+	MaxTokens *int32 `protobuf:"varint,10,opt,name=max_tokens,json=maxTokens,proto3,oneof" json:"max_tokens,omitempty"`
+	// Handling of the sentence overflowing max_characters: "cut", "word", "drop" or "skip".
+	Truncation *string `protobuf:"bytes,11,opt,name=truncation,proto3,oneof" json:"truncation,omitempty"`
+	// Appended to the sentences cut by max_characters.
+	Ellipsis *string `protobuf:"bytes,12,opt,name=ellipsis,proto3,oneof" json:"ellipsis,omitempty"`
+	// Algorithm of ExtractKeywords: "textrank" or "yake".
+	KeywordAlgorithm *string `protobuf:"bytes,13,opt,name=keyword_algorithm,json=keywordAlgorithm,proto3,oneof" json:"keyword_algorithm,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Settings) Reset() {
+	*x = Settings{}
+	mi := &file_tldrpb_tldr_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Settings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Settings) ProtoMessage() {}
+
+func (x *Settings) ProtoReflect() protoreflect.Message {
+	mi := &file_tldrpb_tldr_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Settings.ProtoReflect.Descriptor instead.
+func (*Settings) Descriptor() ([]byte, []int) {
+	return file_tldrpb_tldr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Settings) GetAlgorithm() string {
+	if x != nil && x.Algorithm != nil {
+		return *x.Algorithm
+	}
+	return ""
+}
+
+func (x *Settings) GetWeighing() string {
+	if x != nil && x.Weighing != nil {
+		return *x.Weighing
+	}
+	return ""
+}
+
+func (x *Settings) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *Settings) GetMaxCharacters() int32 {
+	if x != nil && x.MaxCharacters != nil {
+		return *x.MaxCharacters
+	}
+	return 0
+}
+
+func (x *Settings) GetDamping() float64 {
+	if x != nil && x.Damping != nil {
+		return *x.Damping
+	}
+	return 0
+}
+
+func (x *Settings) GetTolerance() float64 {
+	if x != nil && x.Tolerance != nil {
+		return *x.Tolerance
+	}
+	return 0
+}
+
+func (x *Settings) GetThreshold() float64 {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return 0
+}
+
+func (x *Settings) GetPreset() string {
+	if x != nil && x.Preset != nil {
+		return *x.Preset
+	}
+	return ""
+}
+
+func (x *Settings) GetMaxWords() int32 {
+	if x != nil && x.MaxWords != nil {
+		return *x.MaxWords
+	}
+	return 0
+}
+
+func (x *Settings) GetMaxTokens() int32 {
+	if x != nil && x.MaxTokens != nil {
+		return *x.MaxTokens
+	}
+	return 0
+}
+
+func (x *Settings) GetTruncation() string {
+	if x != nil && x.Truncation != nil {
+		return *x.Truncation
+	}
+	return ""
+}
+
+func (x *Settings) GetEllipsis() string {
+	if x != nil && x.Ellipsis != nil {
+		return *x.Ellipsis
+	}
+	return ""
+}
+
+func (x *Settings) GetKeywordAlgorithm() string {
+	if x != nil && x.KeywordAlgorithm != nil {
+		return *x.KeywordAlgorithm
+	}
+	return ""
+}
+
+type SummarizeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Copied into the response, to match them in batches.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Number of sentences of the summary, 0 for the default of the server.
+	Sentences int32 `protobuf:"varint,3,opt,name=sentences,proto3" json:"sentences,omitempty"`
+	// Share of the sentences kept, between 0 and 1, used when sentences is 0.
+	Ratio         float64   `protobuf:"fixed64,4,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	Settings      *Settings `protobuf:"bytes,5,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarizeRequest) Reset() {
+	*x = SummarizeRequest{}
+	mi := &file_tldrpb_tldr_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeRequest) ProtoMessage() {}
+
+func (x *SummarizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tldrpb_tldr_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeRequest.ProtoReflect.Descriptor instead.
+func (*SummarizeRequest) Descriptor() ([]byte, []int) {
+	return file_tldrpb_tldr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SummarizeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SummarizeRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SummarizeRequest) GetSentences() int32 {
+	if x != nil {
+		return x.Sentences
+	}
+	return 0
+}
+
+func (x *SummarizeRequest) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+func (x *SummarizeRequest) GetSettings() *Settings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Sentence is a sentence selected into a summary.
+type Sentence struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the sentence in the text.
+	Index int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Text  string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Byte offsets of the sentence in the text, -1 if unknown.
+	Start int32 `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End   int32 `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	// Score of the sentence, the highest of the text is 1.
+	Score         float64 `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sentence) Reset() {
+	*x = Sentence{}
+	mi := &file_tldrpb_tldr_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sentence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sentence) ProtoMessage() {}
+
+func (x *Sentence) ProtoReflect() protoreflect.Message {
+	mi := &file_tldrpb_tldr_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sentence.ProtoReflect.Descriptor instead.
+func (*Sentence) Descriptor() ([]byte, []int) {
+	return file_tldrpb_tldr_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Sentence) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Sentence) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Sentence) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Sentence) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Sentence) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SummarizeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the language used to process the text, empty if none.
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// Selected sentences, in the order they appear in the text.
+	Sentences []*Sentence `protobuf:"bytes,3,rep,name=sentences,proto3" json:"sentences,omitempty"`
+	// Why the text could not be summarized, only set in batches.
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarizeResponse) Reset() {
+	*x = SummarizeResponse{}
+	mi := &file_tldrpb_tldr_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeResponse) ProtoMessage() {}
+
+func (x *SummarizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tldrpb_tldr_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeResponse.ProtoReflect.Descriptor instead.
+func (*SummarizeResponse) Descriptor() ([]byte, []int) {
+	return file_tldrpb_tldr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SummarizeResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SummarizeResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SummarizeResponse) GetSentences() []*Sentence {
+	if x != nil {
+		return x.Sentences
+	}
+	return nil
+}
+
+func (x *SummarizeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ExtractKeywordsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text  string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Number of keywords, 0 for the default of the server.
+	Keywords      int32     `protobuf:"varint,3,opt,name=keywords,proto3" json:"keywords,omitempty"`
+	Settings      *Settings `protobuf:"bytes,4,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractKeywordsRequest) Reset() {
+	*x = ExtractKeywordsRequest{}
+	mi := &file_tldrpb_tldr_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractKeywordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractKeywordsRequest) ProtoMessage() {}
+
+func (x *ExtractKeywordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tldrpb_tldr_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractKeywordsRequest.ProtoReflect.Descriptor instead.
+func (*ExtractKeywordsRequest) Descriptor() ([]byte, []int) {
+	return file_tldrpb_tldr_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExtractKeywordsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExtractKeywordsRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ExtractKeywordsRequest) GetKeywords() int32 {
+	if x != nil {
+		return x.Keywords
+	}
+	return 0
+}
+
+func (x *ExtractKeywordsRequest) GetSettings() *Settings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Keyword is a word or phrase of a text.
+type Keyword struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// Score of the keyword, the highest of the text is 1.
+	Score         float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Keyword) Reset() {
+	*x = Keyword{}
+	mi := &file_tldrpb_tldr_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Keyword) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Keyword) ProtoMessage() {}
+
+func (x *Keyword) ProtoReflect() protoreflect.Message {
+	mi := &file_tldrpb_tldr_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Keyword.ProtoReflect.Descriptor instead.
+func (*Keyword) Descriptor() ([]byte, []int) {
+	return file_tldrpb_tldr_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Keyword) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Keyword) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type ExtractKeywordsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Language string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// Keywords from the most to the least relevant.
+	Keywords      []*Keyword `protobuf:"bytes,3,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractKeywordsResponse) Reset() {
+	*x = ExtractKeywordsResponse{}
+	mi := &file_tldrpb_tldr_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractKeywordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractKeywordsResponse) ProtoMessage() {}
+
+func (x *ExtractKeywordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tldrpb_tldr_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractKeywordsResponse.ProtoReflect.Descriptor instead.
+func (*ExtractKeywordsResponse) Descriptor() ([]byte, []int) {
+	return file_tldrpb_tldr_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExtractKeywordsResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExtractKeywordsResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ExtractKeywordsResponse) GetKeywords() []*Keyword {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+var File_tldrpb_tldr_proto protoreflect.FileDescriptor
+
+const file_tldrpb_tldr_proto_rawDesc = "" +
+	"\n" +
+	"\x11tldrpb/tldr.proto\x12\atldr.v1\"\x98\x05\n" +
+	"\bSettings\x12!\n" +
+	"\talgorithm\x18\x01 \x01(\tH\x00R\talgorithm\x88\x01\x01\x12\x1f\n" +
+	"\bweighing\x18\x02 \x01(\tH\x01R\bweighing\x88\x01\x01\x12\x1f\n" +
+	"\blanguage\x18\x03 \x01(\tH\x02R\blanguage\x88\x01\x01\x12*\n" +
+	"\x0emax_characters\x18\x04 \x01(\x05H\x03R\rmaxCharacters\x88\x01\x01\x12\x1d\n" +
+	"\adamping\x18\x05 \x01(\x01H\x04R\adamping\x88\x01\x01\x12!\n" +
+	"\ttolerance\x18\x06 \x01(\x01H\x05R\ttolerance\x88\x01\x01\x12!\n" +
+	"\tthreshold\x18\a \x01(\x01H\x06R\tthreshold\x88\x01\x01\x12\x1b\n" +
+	"\x06preset\x18\b \x01(\tH\aR\x06preset\x88\x01\x01\x12 \n" +
+	"\tmax_words\x18\t \x01(\x05H\bR\bmaxWords\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_tokens\x18\n" +
+	" \x01(\x05H\tR\tmaxTokens\x88\x01\x01\x12#\n" +
+	"\n" +
+	"truncation\x18\v \x01(\tH\n" +
+	"R\n" +
+	"truncation\x88\x01\x01\x12\x1f\n" +
+	"\bellipsis\x18\f \x01(\tH\vR\bellipsis\x88\x01\x01\x120\n" +
+	"\x11keyword_algorithm\x18\r \x01(\tH\fR\x10keywordAlgorithm\x88\x01\x01B\f\n" +
+	"\n" +
+	"_algorithmB\v\n" +
+	"\t_weighingB\v\n" +
+	"\t_languageB\x11\n" +
+	"\x0f_max_charactersB\n" +
+	"\n" +
+	"\b_dampingB\f\n" +
+	"\n" +
+	"_toleranceB\f\n" +
+	"\n" +
+	"_thresholdB\t\n" +
+	"\a_presetB\f\n" +
+	"\n" +
+	"_max_wordsB\r\n" +
+	"\v_max_tokensB\r\n" +
+	"\v_truncationB\v\n" +
+	"\t_ellipsisB\x14\n" +
+	"\x12_keyword_algorithm\"\x99\x01\n" +
+	"\x10SummarizeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1c\n" +
+	"\tsentences\x18\x03 \x01(\x05R\tsentences\x12\x14\n" +
+	"\x05ratio\x18\x04 \x01(\x01R\x05ratio\x12-\n" +
+	"\bsettings\x18\x05 \x01(\v2\x11.tldr.v1.SettingsR\bsettings\"r\n" +
+	"\bSentence\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\x05R\x03end\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score\"\x86\x01\n" +
+	"\x11SummarizeResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12/\n" +
+	"\tsentences\x18\x03 \x03(\v2\x11.tldr.v1.SentenceR\tsentences\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x87\x01\n" +
+	"\x16ExtractKeywordsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1a\n" +
+	"\bkeywords\x18\x03 \x01(\x05R\bkeywords\x12-\n" +
+	"\bsettings\x18\x04 \x01(\v2\x11.tldr.v1.SettingsR\bsettings\"3\n" +
+	"\aKeyword\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"s\n" +
+	"\x17ExtractKeywordsResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12,\n" +
+	"\bkeywords\x18\x03 \x03(\v2\x10.tldr.v1.KeywordR\bkeywords2\xf3\x01\n" +
+	"\n" +
+	"Summarizer\x12B\n" +
+	"\tSummarize\x12\x19.tldr.v1.SummarizeRequest\x1a\x1a.tldr.v1.SummarizeResponse\x12K\n" +
+	"\x0eSummarizeBatch\x12\x19.tldr.v1.SummarizeRequest\x1a\x1a.tldr.v1.SummarizeResponse(\x010\x01\x12T\n" +
+	"\x0fExtractKeywords\x12\x1f.tldr.v1.ExtractKeywordsRequest\x1a .tldr.v1.ExtractKeywordsResponseB\x1fZ\x1dgithub.com/didasy/tldr/tldrpbb\x06proto3"
+
+var (
+	file_tldrpb_tldr_proto_rawDescOnce sync.Once
+	file_tldrpb_tldr_proto_rawDescData []byte
+)
+
+func file_tldrpb_tldr_proto_rawDescGZIP() []byte {
+	file_tldrpb_tldr_proto_rawDescOnce.Do(func() {
+		file_tldrpb_tldr_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tldrpb_tldr_proto_rawDesc), len(file_tldrpb_tldr_proto_rawDesc)))
+	})
+	return file_tldrpb_tldr_proto_rawDescData
+}
+
+var file_tldrpb_tldr_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_tldrpb_tldr_proto_goTypes = []any{
+	(*Settings)(nil),                // 0: tldr.v1.Settings
+	(*SummarizeRequest)(nil),        // 1: tldr.v1.SummarizeRequest
+	(*Sentence)(nil),                // 2: tldr.v1.Sentence
+	(*SummarizeResponse)(nil),       // 3: tldr.v1.SummarizeResponse
+	(*ExtractKeywordsRequest)(nil),  // 4: tldr.v1.ExtractKeywordsRequest
+	(*Keyword)(nil),                 // 5: tldr.v1.Keyword
+	(*ExtractKeywordsResponse)(nil), // 6: tldr.v1.ExtractKeywordsResponse
+}
+var file_tldrpb_tldr_proto_depIdxs = []int32{
+	0, // 0: tldr.v1.SummarizeRequest.settings:type_name -> tldr.v1.Settings
+	2, // 1: tldr.v1.SummarizeResponse.sentences:type_name -> tldr.v1.Sentence
+	0, // 2: tldr.v1.ExtractKeywordsRequest.settings:type_name -> tldr.v1.Settings
+	5, // 3: tldr.v1.ExtractKeywordsResponse.keywords:type_name -> tldr.v1.Keyword
+	1, // 4: tldr.v1.Summarizer.Summarize:input_type -> tldr.v1.SummarizeRequest
+	1, // 5: tldr.v1.Summarizer.SummarizeBatch:input_type -> tldr.v1.SummarizeRequest
+	4, // 6: tldr.v1.Summarizer.ExtractKeywords:input_type -> tldr.v1.ExtractKeywordsRequest
+	3, // 7: tldr.v1.Summarizer.Summarize:output_type -> tldr.v1.SummarizeResponse
+	3, // 8: tldr.v1.Summarizer.SummarizeBatch:output_type -> tldr.v1.SummarizeResponse
+	6, // 9: tldr.v1.Summarizer.ExtractKeywords:output_type -> tldr.v1.ExtractKeywordsResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_tldrpb_tldr_proto_init() }
+func file_tldrpb_tldr_proto_init() {
+	if File_tldrpb_tldr_proto != nil {
+		return
+	}
+	file_tldrpb_tldr_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tldrpb_tldr_proto_rawDesc), len(file_tldrpb_tldr_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tldrpb_tldr_proto_goTypes,
+		DependencyIndexes: file_tldrpb_tldr_proto_depIdxs,
+		MessageInfos:      file_tldrpb_tldr_proto_msgTypes,
+	}.Build()
+	File_tldrpb_tldr_proto = out.File
+	file_tldrpb_tldr_proto_goTypes = nil
+	file_tldrpb_tldr_proto_depIdxs = nil
+}
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: tldrpb/tldr.proto
+
+package tldrpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Summarizer_Summarize_FullMethodName       = "/tldr.v1.Summarizer/Summarize"
+	Summarizer_SummarizeBatch_FullMethodName  = "/tldr.v1.Summarizer/SummarizeBatch"
+	Summarizer_ExtractKeywords_FullMethodName = "/tldr.v1.Summarizer/ExtractKeywords"
+)
+
+// SummarizerClient is the client API for Summarizer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Summarizer summarizes texts and extracts their keywords.
+type SummarizerClient interface {
+	// Summarize summarizes a text.
+	Summarize(ctx context.Context, in *SummarizeRequest, opts ...grpc.CallOption) (*SummarizeResponse, error)
+	// SummarizeBatch summarizes every text of the stream and answers them in the same order.
+	// A text that cannot be summarized gets an error in its response instead of ending the stream.
+	SummarizeBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SummarizeRequest, SummarizeResponse], error)
+	// ExtractKeywords finds the keywords of a text.
+	ExtractKeywords(ctx context.Context, in *ExtractKeywordsRequest, opts ...grpc.CallOption) (*ExtractKeywordsResponse, error)
+}
+
+type summarizerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSummarizerClient(cc grpc.ClientConnInterface) SummarizerClient {
+	return &summarizerClient{cc}
+}
+
+func (c *summarizerClient) Summarize(ctx context.Context, in *SummarizeRequest, opts ...grpc.CallOption) (*SummarizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SummarizeResponse)
+	err := c.cc.Invoke(ctx, Summarizer_Summarize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *summarizerClient) SummarizeBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SummarizeRequest, SummarizeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Summarizer_ServiceDesc.Streams[0], Summarizer_SummarizeBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SummarizeRequest, SummarizeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Summarizer_SummarizeBatchClient = grpc.BidiStreamingClient[SummarizeRequest, SummarizeResponse]
+
+func (c *summarizerClient) ExtractKeywords(ctx context.Context, in *ExtractKeywordsRequest, opts ...grpc.CallOption) (*ExtractKeywordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtractKeywordsResponse)
+	err := c.cc.Invoke(ctx, Summarizer_ExtractKeywords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SummarizerServer is the server API for Summarizer service.
+// All implementations must embed UnimplementedSummarizerServer
+// for forward compatibility.
+//
+// Summarizer summarizes texts and extracts their keywords.
+type SummarizerServer interface {
+	// Summarize summarizes a text.
+	Summarize(context.Context, *SummarizeRequest) (*SummarizeResponse, error)
+	// SummarizeBatch summarizes every text of the stream and answers them in the same order.
+	// A text that cannot be summarized gets an error in its response instead of ending the stream.
+	SummarizeBatch(grpc.BidiStreamingServer[SummarizeRequest, SummarizeResponse]) error
+	// ExtractKeywords finds the keywords of a text.
+	ExtractKeywords(context.Context, *ExtractKeywordsRequest) (*ExtractKeywordsResponse, error)
+	mustEmbedUnimplementedSummarizerServer()
+}
+
+// UnimplementedSummarizerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSummarizerServer struct{}
+
+func (UnimplementedSummarizerServer) Summarize(context.Context, *SummarizeRequest) (*SummarizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Summarize not implemented")
+}
+func (UnimplementedSummarizerServer) SummarizeBatch(grpc.BidiStreamingServer[SummarizeRequest, SummarizeResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SummarizeBatch not implemented")
+}
+func (UnimplementedSummarizerServer) ExtractKeywords(context.Context, *ExtractKeywordsRequest) (*ExtractKeywordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtractKeywords not implemented")
+}
+func (UnimplementedSummarizerServer) mustEmbedUnimplementedSummarizerServer() {}
+func (UnimplementedSummarizerServer) testEmbeddedByValue()                    {}
+
+// UnsafeSummarizerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SummarizerServer will
+// result in compilation errors.
+type UnsafeSummarizerServer interface {
+	mustEmbedUnimplementedSummarizerServer()
+}
+
+func RegisterSummarizerServer(s grpc.ServiceRegistrar, srv SummarizerServer) {
+	// If the following call pancis, it indicates UnimplementedSummarizerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Summarizer_ServiceDesc, srv)
+}
+
+func _Summarizer_Summarize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SummarizerServer).Summarize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Summarizer_Summarize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SummarizerServer).Summarize(ctx, req.(*SummarizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Summarizer_SummarizeBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SummarizerServer).SummarizeBatch(&grpc.GenericServerStream[SummarizeRequest, SummarizeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Summarizer_SummarizeBatchServer = grpc.BidiStreamingServer[SummarizeRequest, SummarizeResponse]
+
+func _Summarizer_ExtractKeywords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtractKeywordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SummarizerServer).ExtractKeywords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Summarizer_ExtractKeywords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SummarizerServer).ExtractKeywords(ctx, req.(*ExtractKeywordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Summarizer_ServiceDesc is the grpc.ServiceDesc for Summarizer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Summarizer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tldr.v1.Summarizer",
+	HandlerType: (*SummarizerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Summarize",
+			Handler:    _Summarizer_Summarize_Handler,
+		},
+		{
+			MethodName: "ExtractKeywords",
+			Handler:    _Summarizer_ExtractKeywords_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SummarizeBatch",
+			Handler:       _Summarizer_SummarizeBatch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "tldrpb/tldr.proto",
+}
@@ -0,0 +1,133 @@
+package tldr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// The truncations of the sentence overflowing MaxCharacters
+const (
+	TRUNCATION_CUT  = "cut"  // cut the sentence at the limit
+	TRUNCATION_WORD = "word" // cut the sentence at the last word boundary before the limit
+	TRUNCATION_DROP = "drop" // leave the sentence and the ones after it out
+	TRUNCATION_SKIP = "skip" // leave the sentence out and try the next ones, down to the lower ranked sentences
+)
+
+// checkTruncation returns an error if truncation is unknown
+func checkTruncation(truncation string) error {
+	switch truncation {
+	case TRUNCATION_CUT, TRUNCATION_WORD, TRUNCATION_DROP, TRUNCATION_SKIP:
+		return nil
+	}
+	return fmt.Errorf("tldr: unknown truncation %q", truncation)
+}
+
+// truncate fits the sentences at idx in MaxCharacters, counting the Separator between them,
+// and returns the index and the text of the sentences kept, sorted by index
+func (bag *Bag) truncate(idx []int) ([]int, []string) {
+	if bag.MaxCharacters <= 0 {
+		texts := make([]string, len(idx))
+		for i := range idx {
+			texts[i] = bag.OriginalSentences[idx[i]]
+		}
+		return idx, texts
+	}
+
+	candidates := idx
+	if bag.Truncation == TRUNCATION_SKIP {
+		candidates = bag.skipCandidates(idx)
+	}
+
+	separator := len([]rune(bag.Separator))
+	ellipsis := len([]rune(bag.Ellipsis))
+	kept, texts := []int{}, map[int]string{}
+	length := 0
+	for _, i := range candidates {
+		if len(kept) == len(idx) {
+			break
+		}
+		sentence := bag.OriginalSentences[i]
+		if len(kept) > 0 {
+			length += separator
+		}
+		room := bag.MaxCharacters - length
+		if n := len([]rune(sentence)); n <= room {
+			kept = append(kept, i)
+			texts[i] = sentence
+			length += n
+			continue
+		}
+		if len(kept) > 0 {
+			length -= separator
+		}
+
+		if bag.Truncation == TRUNCATION_SKIP {
+			continue
+		}
+		cut := ""
+		switch bag.Truncation {
+		case TRUNCATION_WORD:
+			cut = cutWord(sentence, room-ellipsis)
+		case TRUNCATION_DROP:
+		default:
+			if room > ellipsis {
+				cut = string([]rune(sentence)[:room-ellipsis])
+			}
+		}
+		if cut != "" {
+			kept = append(kept, i)
+			texts[i] = cut + bag.Ellipsis
+		}
+		break
+	}
+
+	sort.Ints(kept)
+	sentences := make([]string, len(kept))
+	for k, i := range kept {
+		sentences[k] = texts[i]
+	}
+	return kept, sentences
+}
+
+// skipCandidates returns the sentences at idx from the highest ranked, followed by the lower ranked ones
+// not at idx, unless the sentences were selected within MaxWords or MaxTokens
+func (bag *Bag) skipCandidates(idx []int) []int {
+	selected := make(map[int]bool, len(idx))
+	for _, i := range idx {
+		selected[i] = true
+	}
+	candidates := make([]int, 0, len(bag.Ranks))
+	for _, i := range bag.Ranks {
+		if selected[i] {
+			candidates = append(candidates, i)
+		}
+	}
+	if bag.MaxWords > 0 || bag.MaxTokens > 0 {
+		return candidates
+	}
+	for _, i := range bag.Ranks {
+		if !selected[i] && i >= 0 && i < len(bag.OriginalSentences) {
+			candidates = append(candidates, i)
+		}
+	}
+	return candidates
+}
+
+// cutWord cuts sentence at the last word boundary within n runes, without the spaces and punctuation ending it.
+// It returns an empty string if no word fits.
+func cutWord(sentence string, n int) string {
+	runes := []rune(sentence)
+	if n <= 0 {
+		return ""
+	}
+	if n < len(runes) && !unicode.IsSpace(runes[n]) {
+		for n > 0 && !unicode.IsSpace(runes[n-1]) {
+			n--
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:n]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+}
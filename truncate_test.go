@@ -0,0 +1,93 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Truncation", func() {
+	sentences := []string{
+		"The quick brown fox jumps over the lazy dog.",
+		"Cats sleep all day long.",
+		"Birds sing.",
+	}
+	var bag *Bag
+
+	BeforeEach(func() {
+		bag = New()
+		bag.Algorithm = "custom"
+		bag.SetCustomAlgorithm(func(e []*Edge) []int {
+			return []int{0, 1, 2}
+		})
+	})
+
+	It("Should cut the overflowing sentence at the limit", func() {
+		bag.MaxCharacters = 50
+		sums, err := bag.SummarizeSentences(sentences, 3)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal([]string{sentences[0], "Cats s"}))
+	})
+
+	It("Should not return an empty sentence when the limit is reached", func() {
+		bag.MaxCharacters = 44
+		sums, err := bag.SummarizeSentences(sentences, 3)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal(sentences[:1]))
+	})
+
+	It("Should cut the overflowing sentence at a word boundary with the ellipsis", func() {
+		bag.MaxCharacters = 60
+		bag.Truncation = TRUNCATION_WORD
+		bag.Ellipsis = "…"
+		sums, err := bag.SummarizeSentences(sentences, 3)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal([]string{sentences[0], "Cats sleep all…"}))
+
+		bag.MaxCharacters = 3
+		sums, err = bag.SummarizeSentences(sentences, 3)
+		Expect(err).To(BeNil())
+		Expect(sums).To(BeEmpty())
+	})
+
+	It("Should drop the overflowing sentence", func() {
+		bag.MaxCharacters = 60
+		bag.Truncation = TRUNCATION_DROP
+		sums, err := bag.SummarizeSentences(sentences, 3)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal(sentences[:1]))
+	})
+
+	It("Should skip to a lower ranked sentence that fits", func() {
+		bag.MaxCharacters = 60
+		bag.Truncation = TRUNCATION_SKIP
+		sums, err := bag.SummarizeSentences(sentences, 2)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal([]string{sentences[0], sentences[2]}))
+
+		summary, err := bag.SummarizeDetailed("The quick brown fox jumps over the lazy dog. Cats sleep all day long. Birds sing.", 2)
+		Expect(err).To(BeNil())
+		Expect(summary.Sentences).To(HaveLen(2))
+		Expect(summary.Sentences[1].Index).To(Equal(2))
+		Expect(summary.Sentences[1].Text).To(Equal(sentences[2]))
+	})
+
+	It("Should count the separators in the limit", func() {
+		bag.Separator = " "
+		bag.MaxCharacters = 69
+		sums, err := bag.SummarizeSentences(sentences, 2)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal(sentences[:2]))
+
+		bag.MaxCharacters = 68
+		sums, err = bag.SummarizeSentences(sentences, 2)
+		Expect(err).To(BeNil())
+		Expect(sums).To(Equal([]string{sentences[0], "Cats sleep all day long"}))
+	})
+
+	It("Should reject unknown truncations", func() {
+		truncation := "fold"
+		Expect(bag.ApplyConfig(&Config{Truncation: &truncation})).To(MatchError(`tldr: unknown truncation "fold"`))
+	})
+})
@@ -226,3 +226,10 @@ func SanitizeWord(word string) string {
 
 	return word
 }
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,307 @@
+package tldr
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// keyphrases more similar than this to a better one, by Distance, are left out by YAKE
+	yakeDeduplication = 0.9
+)
+
+// yakeTerm holds the statistics of a word of the text for YAKE
+type yakeTerm struct {
+	count       int            // times the word appears
+	capitalized int            // times it starts with a capital letter, not at the start of a sentence
+	acronyms    int            // times it is all in capital letters
+	sentences   []int          // sentence of every time it appears, in order
+	left, right map[string]int // words right before and after it
+	score       float64
+}
+
+// yakeToken is a word of a chunk of text, between punctuation and words that cannot be keywords
+type yakeToken struct {
+	key  string // normalized word, or the stop word
+	form string
+	stop bool
+}
+
+// yakeKeywords extracts the keywords of text with YAKE, from the statistics of the words in the text alone:
+// their casing, position, frequency, relatedness to the words around them and dispersion over the sentences.
+// Sentences are split into words with the word tokenizer, and words with the same stem are the same term.
+// Keyphrases of up to 3 words, not starting or ending with a stop word, are scored from their words,
+// and the ones whose stems are too similar to a better one are left out, until num keyphrases are found,
+// or all if num is less than 1.
+func (bag *Bag) yakeKeywords(text string, num int) []*Keyword {
+	normalizer := bag.keywordWords(text)
+	// words with the same stem are the same term, with the stemmer of the language detected if there is none
+	if normalizer.stemmer == nil && normalizer.dict == nil && normalizer.detected != nil {
+		normalizer.stemmer = normalizer.detected.Stemmer
+	}
+
+	terms := make(map[string]*yakeTerm)
+	term := func(key string) *yakeTerm {
+		t, exists := terms[key]
+		if !exists {
+			t = &yakeTerm{left: map[string]int{}, right: map[string]int{}}
+			terms[key] = t
+		}
+		return t
+	}
+
+	var chunks [][]*yakeToken
+	sentences := bag.splitBlock(text)
+	for s, sentence := range sentences {
+		var chunk []*yakeToken
+		for i, word := range append(bag.yakeWords(sentence), yakeWord{}) {
+			token := &yakeToken{form: word.word}
+			if isKeyword(word.word) && normalizer.isStopWord(word.word) {
+				token.key, token.stop = word.word, true
+			} else {
+				token.key = normalizer.normalize(word.word)
+			}
+			if (token.key == "" || word.delimited) && len(chunk) > 0 {
+				chunks = append(chunks, chunk)
+				chunk = nil
+			}
+			if token.key == "" {
+				continue
+			}
+
+			t := term(token.key)
+			t.count++
+			t.sentences = append(t.sentences, s)
+			if letters := onlyLetters(word.text); i > 0 && len(letters) > 0 {
+				switch {
+				case len(letters) > 1 && strings.ToUpper(letters) == letters:
+					t.acronyms++
+				case unicode.IsUpper([]rune(letters)[0]):
+					t.capitalized++
+				}
+			}
+			if last := len(chunk) - 1; last >= 0 {
+				t.left[chunk[last].key]++
+				term(chunk[last].key).right[token.key]++
+			}
+			chunk = append(chunk, token)
+		}
+	}
+
+	// score the words, the lower the better, from the statistics of the words that are not stop words,
+	// but the most frequent word may be one
+	var counts []float64
+	maxCount := 0
+	for _, chunk := range chunks {
+		for _, token := range chunk {
+			t := terms[token.key]
+			if t.count > maxCount {
+				maxCount = t.count
+			}
+			if !token.stop && t.score == 0 {
+				t.score = -1 // counted
+				counts = append(counts, float64(t.count))
+			}
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	mean, deviation := meanDeviation(counts)
+	for _, chunk := range chunks {
+		for _, token := range chunk {
+			t := terms[token.key]
+			if token.stop || t.score > 0 {
+				continue
+			}
+			tf := float64(t.count)
+			in := uniqInts(append([]int(nil), t.sentences...))
+			casing := float64(max(t.capitalized, t.acronyms)) / (1 + math.Log(tf))
+			position := math.Log(math.Log(3 + median(in)))
+			frequency := tf / (mean + deviation)
+			relatedness := 1 + (neighbours(t.left)+neighbours(t.right))*tf/float64(maxCount)
+			different := float64(len(in)) / float64(len(sentences))
+			t.score = relatedness * position / (casing + frequency/relatedness + different/relatedness)
+		}
+	}
+
+	// score the keyphrases from their words
+	type yakeCandidate struct {
+		keywordCandidate
+		count int
+	}
+	candidates := make(map[string]*yakeCandidate)
+	for _, chunk := range chunks {
+		for start := range chunk {
+			if chunk[start].stop {
+				continue
+			}
+			for end := start; end < len(chunk) && end-start < keyphraseWords; end++ {
+				if chunk[end].stop {
+					continue
+				}
+				keys, forms := make([]string, 0, end-start+1), make([]string, 0, end-start+1)
+				for _, token := range chunk[start : end+1] {
+					keys, forms = append(keys, token.key), append(forms, token.form)
+				}
+				key := strings.Join(keys, " ")
+				candidate, exists := candidates[key]
+				if !exists {
+					candidate = &yakeCandidate{keywordCandidate: keywordCandidate{words: keys, forms: map[string]int{}}}
+					candidates[key] = candidate
+				}
+				candidate.forms[strings.Join(forms, " ")]++
+				candidate.count++
+			}
+		}
+	}
+
+	type yakeKeyword struct {
+		*Keyword
+		key string // stemmed words
+	}
+	ranked := make([]yakeKeyword, 0, len(candidates))
+	for key, candidate := range candidates {
+		product, sum := 1.0, 0.0
+		for _, word := range candidate.words {
+			// stop words inside the keyphrase have no score
+			if score := terms[word].score; score > 0 {
+				product *= score
+				sum += score
+			}
+		}
+		score := product / (float64(candidate.count) * (1 + sum))
+		ranked = append(ranked, yakeKeyword{&Keyword{Text: candidate.form(), Score: 1 / score}, key})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Text < ranked[j].Text
+	})
+
+	// the stemmed words are compared, so the forms of a keyphrase are never kept twice
+	var kept []string
+	keywords := make([]*Keyword, 0, len(ranked))
+	for _, keyword := range ranked {
+		if num > 0 && len(keywords) == num {
+			break
+		}
+		duplicate := false
+		for _, key := range kept {
+			if Distance(keyword.key, key) > yakeDeduplication {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			kept = append(kept, keyword.key)
+			keywords = append(keywords, keyword.Keyword)
+		}
+	}
+	return keywords
+}
+
+// yakeWord is a word of a sentence for YAKE
+type yakeWord struct {
+	word      string // as returned by the word tokenizer
+	text      string // as it appears in the sentence, the word itself if it cannot be found
+	delimited bool   // punctuation ending a keyphrase comes right before it
+}
+
+// yakeWords splits sentence into words with the word tokenizer, like the summarizer does,
+// and locates every word in the sentence for its casing and the punctuation before it
+func (bag *Bag) yakeWords(sentence string) []yakeWord {
+	lower := strings.ToLower(sentence)
+	if len(lower) != len(sentence) {
+		// the offsets would not match, the casing is lost
+		lower = sentence
+	}
+	words := bag.wordTokenizer(sentence)
+	located := make([]yakeWord, 0, len(words))
+	from := 0
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		w := yakeWord{word: word, text: word}
+		if at := indexWord(lower, strings.ToLower(word), from); at >= 0 {
+			w.text = sentence[at : at+len(word)]
+			w.delimited = keyphraseDelimiter.MatchString(sentence[from:at])
+			from = at + len(word)
+		}
+		located = append(located, w)
+	}
+	return located
+}
+
+// indexWord returns the index of the first instance of word in s from the index from,
+// not preceded or followed by a letter or digit, or -1 if there is none
+func indexWord(s, word string, from int) int {
+	isWord := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	for from <= len(s) {
+		at := strings.Index(s[from:], word)
+		if at < 0 {
+			return -1
+		}
+		at += from
+		before, _ := utf8.DecodeLastRuneInString(s[:at])
+		after, _ := utf8.DecodeRuneInString(s[at+len(word):])
+		if (at == 0 || !isWord(before)) && (at+len(word) == len(s) || !isWord(after)) {
+			return at
+		}
+		_, size := utf8.DecodeRuneInString(s[at:])
+		from = at + size
+	}
+	return -1
+}
+
+// neighbours is the share of different words among the words next to a word, 0 if there is none
+func neighbours(words map[string]int) float64 {
+	total := 0
+	for _, count := range words {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(len(words)) / float64(total)
+}
+
+// meanDeviation returns the mean and standard deviation of values
+func meanDeviation(values []float64) (float64, float64) {
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// median returns the median of sorted values
+func median(values []int) float64 {
+	n := len(values)
+	if n%2 == 1 {
+		return float64(values[n/2])
+	}
+	return float64(values[n/2-1]+values[n/2]) / 2
+}
+
+// onlyLetters returns the letters of word
+func onlyLetters(word string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return r
+		}
+		return -1
+	}, word)
+}
@@ -0,0 +1,115 @@
+package tldr_test
+
+import (
+	. "github.com/didasy/tldr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+	"unicode"
+)
+
+var _ = Describe("YAKE", func() {
+	var bag *Bag
+
+	BeforeEach(func() {
+		bag = New()
+		bag.KeywordAlgorithm = KEYWORDS_YAKE
+	})
+
+	It("Should rank the keyphrases of a short text", func() {
+		text := "Machine learning models need data. Machine learning engineers clean the data before training."
+		keywords, err := bag.ExtractKeywords(text, 5)
+		Expect(err).To(BeNil())
+		Expect(keywords.Keywords).To(HaveLen(5))
+		Expect(keywords.Keywords[0].Score).To(Equal(1.0))
+		texts := []string{}
+		for i, keyword := range keywords.Keywords {
+			texts = append(texts, keyword.Text)
+			Expect(keyword.Text).NotTo(MatchRegexp(`^(the|before) |\b(the|before)$`))
+			if i > 0 {
+				Expect(keyword.Score).To(BeNumerically("<=", keywords.Keywords[i-1].Score))
+			}
+		}
+		Expect(texts[:3]).To(ContainElement("machine learning"))
+	})
+
+	It("Should prefer capitalized words and acronyms", func() {
+		rank := func(text, word string) int {
+			keywords, err := bag.ExtractKeywords(text, 0)
+			Expect(err).To(BeNil())
+			for i, keyword := range keywords.Keywords {
+				if keyword.Text == word {
+					return i
+				}
+			}
+			return len(keywords.Keywords)
+		}
+		text := "The team at NASA launched a rocket. Later the team launched another rocket. NASA said the rocket worked."
+		Expect(rank(text, "nasa")).To(BeNumerically("<", rank(strings.ToLower(text), "nasa")))
+	})
+
+	It("Should leave out keyphrases too similar to a better one", func() {
+		text := "Star wars fans love star wars. The star war museum shows star wars sets and star wars art."
+		stem := func(text string) string {
+			words := strings.Fields(text)
+			for i, word := range words {
+				words[i] = StemEnglish(word)
+			}
+			return strings.Join(words, " ")
+		}
+		keywords, err := bag.ExtractKeywords(text, 0)
+		Expect(err).To(BeNil())
+		for i, keyword := range keywords.Keywords {
+			for _, other := range keywords.Keywords[:i] {
+				Expect(Distance(stem(keyword.Text), stem(other.Text))).To(BeNumerically("<=", 0.9))
+			}
+		}
+		texts := []string{}
+		for _, keyword := range keywords.Keywords {
+			texts = append(texts, keyword.Text)
+		}
+		Expect(texts).To(ContainElement("star wars"))
+		Expect(texts).NotTo(ContainElement("star war"))
+	})
+
+	It("Should split whole sentences with the word tokenizer", func() {
+		var sentences []string
+		bag.SetWordTokenizer(func(sentence string) []string {
+			sentences = append(sentences, sentence)
+			return strings.FieldsFunc(strings.ToLower(sentence), func(r rune) bool {
+				return !unicode.IsLetter(r)
+			})
+		})
+		text := "Fans love Star Wars-related art. The museum shows Star Wars-related sets, and Star Wars-related art."
+		keywords, err := bag.ExtractKeywords(text, 0)
+		Expect(err).To(BeNil())
+		Expect(sentences).To(ConsistOf(
+			"Fans love Star Wars-related art.",
+			"The museum shows Star Wars-related sets, and Star Wars-related art.",
+		))
+		texts := []string{}
+		for _, keyword := range keywords.Keywords {
+			texts = append(texts, keyword.Text)
+		}
+		Expect(texts).To(ContainElement("star wars related"))
+		// the comma ends the keyphrases
+		Expect(texts).NotTo(ContainElement("sets and star"))
+	})
+
+	It("Should return no keywords for an empty text", func() {
+		keywords, err := bag.ExtractKeywords("", 3)
+		Expect(err).To(BeNil())
+		Expect(keywords.Keywords).To(BeEmpty())
+	})
+
+	It("Should reject unknown algorithms", func() {
+		bag.KeywordAlgorithm = "lda"
+		_, err := bag.ExtractKeywords("Some text.", 3)
+		Expect(err).To(MatchError(`tldr: unknown keyword algorithm "lda"`))
+
+		algorithm := "lda"
+		Expect(New().ApplyConfig(&Config{KeywordAlgorithm: &algorithm})).NotTo(Succeed())
+	})
+})